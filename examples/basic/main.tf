@@ -35,7 +35,6 @@ resource "openstack_instance" "demo_instance" {
   connection {
     user = "ubuntu"
     key_file = "key/id_rsa"
-    host = "${openstack_instance.demo_instance.network_info.cybera_ipv6}"
   }
 
   provisioner file {
@@ -0,0 +1,110 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// lookupCacheTTL bounds how long lookups are reused. Resources created
+// outside of Terraform show up once their entry expires.
+const lookupCacheTTL = 5 * time.Minute
+
+// lookupCache holds the results of read-only lookups such as image and
+// flavor lists, so that many resources in one run share a single API call.
+// Entries are keyed by region, kind and ID. Concurrent callers asking for
+// the same key wait for the first caller's request instead of repeating it.
+// Errors are never cached.
+type lookupCache struct {
+	sync.Mutex
+
+	ttl     time.Duration
+	entries map[string]*lookupCacheEntry
+}
+
+type lookupCacheEntry struct {
+	ready   chan struct{}
+	value   interface{}
+	err     error
+	expires time.Time
+}
+
+func newLookupCache(ttl time.Duration) *lookupCache {
+	return &lookupCache{
+		ttl:     ttl,
+		entries: make(map[string]*lookupCacheEntry),
+	}
+}
+
+func lookupCacheKey(region, kind, id string) string {
+	return fmt.Sprintf("%s/%s/%s", region, kind, id)
+}
+
+// get returns the cached value for the key, calling load if there is no
+// live entry.
+func (c *lookupCache) get(region, kind, id string, load func() (interface{}, error)) (interface{}, error) {
+	key := lookupCacheKey(region, kind, id)
+
+	c.Lock()
+	e, ok := c.entries[key]
+	// a zero expiry means the entry is still being loaded
+	if ok && (e.expires.IsZero() || time.Now().Before(e.expires)) {
+		c.Unlock()
+		<-e.ready
+		return e.value, e.err
+	}
+
+	e = &lookupCacheEntry{ready: make(chan struct{})}
+	c.entries[key] = e
+	c.Unlock()
+
+	value, err := load()
+
+	c.Lock()
+	e.value, e.err = value, err
+	if err != nil {
+		if c.entries[key] == e {
+			delete(c.entries, key)
+		}
+	} else {
+		e.expires = time.Now().Add(c.ttl)
+	}
+	c.Unlock()
+	close(e.ready)
+
+	return value, err
+}
+
+// invalidate drops every entry of the given kind in the region. Resources
+// call it after writes that change what a lookup of that kind returns.
+func (c *lookupCache) invalidate(region, kind string) {
+	prefix := lookupCacheKey(region, kind, "")
+
+	c.Lock()
+	defer c.Unlock()
+
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			log.Printf("[DEBUG] Invalidating lookup cache entry: %s", key)
+			delete(c.entries, key)
+		}
+	}
+}
+
+// cachedLookup runs a lookup through the provider's cache, in the region
+// of the resource.
+func cachedLookup(d *schema.ResourceData, meta interface{}, kind, id string, load func() (interface{}, error)) (interface{}, error) {
+	config := meta.(*Config)
+	return config.cache.get(d.Get("region").(string), kind, id, load)
+}
+
+// invalidateLookups drops cached lookups of the given kind in the region
+// of the resource.
+func invalidateLookups(d *schema.ResourceData, meta interface{}, kind string) {
+	config := meta.(*Config)
+	config.cache.invalidate(d.Get("region").(string), kind)
+}
@@ -0,0 +1,115 @@
+package openstack
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestLookupCache(t *testing.T) {
+	c := newLookupCache(time.Minute)
+
+	var loads int
+	load := func() (interface{}, error) {
+		loads++
+		return loads, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		v, err := c.get("RegionOne", "flavor", "list", load)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if v.(int) != 1 {
+			t.Fatalf("Expected the cached value, got: %v", v)
+		}
+	}
+
+	if _, err := c.get("RegionTwo", "flavor", "list", load); err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if loads != 2 {
+		t.Fatalf("Expected regions to be cached separately, got %d loads", loads)
+	}
+
+	c.invalidate("RegionOne", "flavor")
+	v, _ := c.get("RegionOne", "flavor", "list", load)
+	if v.(int) != 3 {
+		t.Fatalf("Expected a reload after invalidate, got: %v", v)
+	}
+
+	c.invalidate("RegionOne", "image")
+	v, _ = c.get("RegionOne", "flavor", "list", load)
+	if v.(int) != 3 {
+		t.Fatalf("Expected other kinds to be left alone, got: %v", v)
+	}
+}
+
+func TestLookupCache_expiry(t *testing.T) {
+	c := newLookupCache(time.Millisecond)
+
+	var loads int
+	load := func() (interface{}, error) {
+		loads++
+		return loads, nil
+	}
+
+	c.get("RegionOne", "image", "list", load)
+	time.Sleep(5 * time.Millisecond)
+	c.get("RegionOne", "image", "list", load)
+
+	if loads != 2 {
+		t.Fatalf("Expected an expired entry to be reloaded, got %d loads", loads)
+	}
+}
+
+func TestLookupCache_errors(t *testing.T) {
+	c := newLookupCache(time.Minute)
+
+	var loads int
+	load := func() (interface{}, error) {
+		loads++
+		return nil, fmt.Errorf("failed")
+	}
+
+	for i := 0; i < 2; i++ {
+		if _, err := c.get("RegionOne", "image", "list", load); err == nil {
+			t.Fatal("Expected the load error")
+		}
+	}
+
+	if loads != 2 {
+		t.Fatalf("Expected errors not to be cached, got %d loads", loads)
+	}
+}
+
+func TestLookupCache_concurrent(t *testing.T) {
+	c := newLookupCache(time.Minute)
+
+	var mu sync.Mutex
+	var loads int
+	load := func() (interface{}, error) {
+		mu.Lock()
+		loads++
+		mu.Unlock()
+		time.Sleep(10 * time.Millisecond)
+		return "flavors", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if v, err := c.get("RegionOne", "flavor", "list", load); err != nil || v.(string) != "flavors" {
+				t.Errorf("Unexpected result: %v, %v", v, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if loads != 1 {
+		t.Fatalf("Expected concurrent lookups to share one load, got %d", loads)
+	}
+}
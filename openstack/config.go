@@ -20,10 +20,12 @@ type Config struct {
 	DomainName              string
 	BlockStorageAPIVersion  string
 	ComputeAPIVersion       string
+	ImageAPIVersion         string
 	NetworkingAPIVersion    string
 	ObjectStorageAPIVersion string
 
 	osClient *gophercloud.ProviderClient
+	cache    *lookupCache
 }
 
 func (c *Config) NewClient() error {
@@ -45,6 +47,7 @@ func (c *Config) NewClient() error {
 	}
 
 	c.osClient = client
+	c.cache = newLookupCache(lookupCacheTTL)
 
 	log.Printf("[INFO] Openstack Client configured for user %s", c.Username)
 
@@ -60,6 +63,8 @@ func getClient(clientType string, d *schema.ResourceData, meta interface{}) (*go
 		return config.blockStorageClient(region)
 	case "compute":
 		return config.computeClient(region)
+	case "image":
+		return config.imageClient(region)
 	case "network":
 		return config.networkingClient(region)
 	case "object":
@@ -87,6 +92,28 @@ func (c *Config) computeClient(region string) (*gophercloud.ServiceClient, error
 	return nil, fmt.Errorf("compute api version not supported: %v", c.ComputeAPIVersion)
 }
 
+// gophercloud has no image service client, so build one from the catalog
+func (c *Config) imageClient(region string) (*gophercloud.ServiceClient, error) {
+	if c.ImageAPIVersion == "2" {
+		eo := gophercloud.EndpointOpts{
+			Region: region,
+		}
+		eo.ApplyDefaults("image")
+
+		url, err := c.osClient.EndpointLocator(eo)
+		if err != nil {
+			return nil, err
+		}
+
+		return &gophercloud.ServiceClient{
+			ProviderClient: c.osClient,
+			Endpoint:       url,
+			ResourceBase:   url + "v2/",
+		}, nil
+	}
+	return nil, fmt.Errorf("image api version not supported: %v", c.ImageAPIVersion)
+}
+
 func (c *Config) networkingClient(region string) (*gophercloud.ServiceClient, error) {
 	if c.NetworkingAPIVersion == "2" {
 		return openstack.NewNetworkV2(c.osClient, gophercloud.EndpointOpts{
@@ -0,0 +1,165 @@
+package openstack
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/compute/v2/flavors"
+)
+
+type flavorRequirements struct {
+	MinVCPUs   int
+	MinRAM     int
+	MinDisk    int
+	ExtraSpecs map[string]string
+}
+
+func getFlavorExtraSpecs(client *gophercloud.ServiceClient, flavorId string) (map[string]string, error) {
+	var extraSpecs map[string]string
+	ep := fmt.Sprintf("flavors/%s/os-extra_specs", flavorId)
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL(ep),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				ExtraSpecs *map[string]string `json:"extra_specs"`
+			}{&extraSpecs},
+		},
+	)
+
+	return extraSpecs, err
+}
+
+// selectFlavor returns the smallest flavor that meets the requirements.
+// Flavors are ordered by RAM, then vCPUs, then disk, then name and ID.
+// Extra specs are only fetched for flavors that meet the other
+// requirements.
+func selectFlavor(flavorList []flavors.Flavor, reqs flavorRequirements, extraSpecs func(string) (map[string]string, error)) (*flavors.Flavor, error) {
+	var candidates []flavors.Flavor
+	for _, f := range flavorList {
+		if f.VCPUs >= reqs.MinVCPUs && f.RAM >= reqs.MinRAM && f.Disk >= reqs.MinDisk {
+			candidates = append(candidates, f)
+		}
+	}
+
+	sort.Sort(flavorsBySize(candidates))
+
+	for _, f := range candidates {
+		if len(reqs.ExtraSpecs) == 0 {
+			return &f, nil
+		}
+
+		specs, err := extraSpecs(f.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		match := true
+		for k, v := range reqs.ExtraSpecs {
+			if specs[k] != v {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			return &f, nil
+		}
+	}
+
+	return nil, fmt.Errorf("No flavor meets the requirements: %d vCPUs, %d MB RAM, %d GB disk, extra specs %v",
+		reqs.MinVCPUs, reqs.MinRAM, reqs.MinDisk, reqs.ExtraSpecs)
+}
+
+type flavorsBySize []flavors.Flavor
+
+func (a flavorsBySize) Len() int      { return len(a) }
+func (a flavorsBySize) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a flavorsBySize) Less(i, j int) bool {
+	if a[i].RAM != a[j].RAM {
+		return a[i].RAM < a[j].RAM
+	}
+	if a[i].VCPUs != a[j].VCPUs {
+		return a[i].VCPUs < a[j].VCPUs
+	}
+	if a[i].Disk != a[j].Disk {
+		return a[i].Disk < a[j].Disk
+	}
+	// break ties so the same flavor is picked whatever order nova lists
+	// them in
+	if a[i].Name != a[j].Name {
+		return a[i].Name < a[j].Name
+	}
+	return a[i].ID < a[j].ID
+}
+
+// lookupFlavor resolves a flavor block to the smallest matching flavor.
+func lookupFlavor(client *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}, lookup map[string]interface{}) (*flavors.Flavor, error) {
+	reqs := flavorRequirements{
+		MinVCPUs:   lookup["min_vcpus"].(int),
+		MinRAM:     lookup["min_ram"].(int),
+		MinDisk:    lookup["min_disk"].(int),
+		ExtraSpecs: make(map[string]string),
+	}
+
+	if v, ok := lookup["extra_specs"].(map[string]interface{}); ok {
+		for k, v := range v {
+			reqs.ExtraSpecs[k] = v.(string)
+		}
+	}
+
+	flavorList, err := listFlavors(client, d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return selectFlavor(flavorList, reqs, func(flavorId string) (map[string]string, error) {
+		v, err := cachedLookup(d, meta, "flavor", flavorId+"/extra_specs", func() (interface{}, error) {
+			return getFlavorExtraSpecs(client, flavorId)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return v.(map[string]string), nil
+	})
+}
+
+func flavorLookupSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ForceNew:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"flavor_id", "flavor_name"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"min_vcpus": &schema.Schema{
+					Type:     schema.TypeInt,
+					Optional: true,
+					ForceNew: true,
+				},
+				"min_ram": &schema.Schema{
+					Type:     schema.TypeInt,
+					Optional: true,
+					ForceNew: true,
+				},
+				"min_disk": &schema.Schema{
+					Type:     schema.TypeInt,
+					Optional: true,
+					ForceNew: true,
+				},
+				"extra_specs": &schema.Schema{
+					Type:     schema.TypeMap,
+					Optional: true,
+					ForceNew: true,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,82 @@
+package openstack
+
+import (
+	"testing"
+
+	"github.com/rackspace/gophercloud/openstack/compute/v2/flavors"
+)
+
+var testFlavors = []flavors.Flavor{
+	flavors.Flavor{ID: "1", Name: "m1.large", VCPUs: 4, RAM: 8192, Disk: 80},
+	flavors.Flavor{ID: "2", Name: "m1.small", VCPUs: 1, RAM: 2048, Disk: 20},
+	flavors.Flavor{ID: "3", Name: "m1.medium", VCPUs: 2, RAM: 4096, Disk: 40},
+	flavors.Flavor{ID: "4", Name: "m1.medium.ssd", VCPUs: 2, RAM: 4096, Disk: 40},
+}
+
+var testFlavorExtraSpecs = map[string]map[string]string{
+	"4": map[string]string{"disk_type": "ssd"},
+}
+
+func testGetFlavorExtraSpecs(flavorId string) (map[string]string, error) {
+	return testFlavorExtraSpecs[flavorId], nil
+}
+
+func TestSelectFlavor(t *testing.T) {
+	flavor, err := selectFlavor(testFlavors, flavorRequirements{MinVCPUs: 2}, testGetFlavorExtraSpecs)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if flavor.ID != "3" {
+		t.Fatalf("Expected the smallest flavor with 2 vCPUs, got: %v", flavor.ID)
+	}
+
+	flavor, err = selectFlavor(testFlavors, flavorRequirements{MinRAM: 4097}, testGetFlavorExtraSpecs)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if flavor.ID != "1" {
+		t.Fatalf("Expected the smallest flavor with over 4096 MB RAM, got: %v", flavor.ID)
+	}
+
+	reqs := flavorRequirements{
+		MinDisk:    20,
+		ExtraSpecs: map[string]string{"disk_type": "ssd"},
+	}
+	flavor, err = selectFlavor(testFlavors, reqs, testGetFlavorExtraSpecs)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if flavor.ID != "4" {
+		t.Fatalf("Expected the flavor with matching extra specs, got: %v", flavor.ID)
+	}
+}
+
+func TestSelectFlavor_ties(t *testing.T) {
+	reversed := make([]flavors.Flavor, len(testFlavors))
+	for i, f := range testFlavors {
+		reversed[len(testFlavors)-1-i] = f
+	}
+
+	for _, fs := range [][]flavors.Flavor{testFlavors, reversed} {
+		flavor, err := selectFlavor(fs, flavorRequirements{MinVCPUs: 2}, testGetFlavorExtraSpecs)
+		if err != nil {
+			t.Fatalf("err: %v", err)
+		}
+		if flavor.ID != "3" {
+			t.Fatalf("Expected flavors of the same size to be picked by name, got: %v", flavor.ID)
+		}
+	}
+}
+
+func TestSelectFlavor_noMatch(t *testing.T) {
+	if _, err := selectFlavor(testFlavors, flavorRequirements{MinVCPUs: 8}, testGetFlavorExtraSpecs); err == nil {
+		t.Fatal("Expected an error when no flavor is big enough")
+	}
+
+	reqs := flavorRequirements{
+		ExtraSpecs: map[string]string{"disk_type": "nvme"},
+	}
+	if _, err := selectFlavor(testFlavors, reqs, testGetFlavorExtraSpecs); err == nil {
+		t.Fatal("Expected an error when no flavor has the extra specs")
+	}
+}
@@ -0,0 +1,46 @@
+package openstack
+
+import (
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+)
+
+// GlanceImageDetail is a glance v2 image without its custom properties.
+type GlanceImageDetail struct {
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	DiskFormat      string `json:"disk_format"`
+	ContainerFormat string `json:"container_format"`
+	Size            int64  `json:"size"`
+}
+
+func getGlanceImage(client *gophercloud.ServiceClient, imageId string) (*GlanceImageDetail, error) {
+	var image GlanceImageDetail
+
+	// glance v2 doesn't wrap images in an object
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("images", imageId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results:     &image,
+			OkCodes:     []int{200},
+		},
+	)
+
+	return &image, err
+}
+
+func deleteGlanceImage(client *gophercloud.ServiceClient, imageId string) error {
+	_, err := perigee.Request(
+		"DELETE",
+		client.ServiceURL("images", imageId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			OkCodes:     []int{204},
+		},
+	)
+
+	return err
+}
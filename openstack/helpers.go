@@ -3,12 +3,14 @@ package openstack
 import (
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/racker/perigee"
 	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/blockstorage/v1/snapshots"
 	"github.com/rackspace/gophercloud/openstack/blockstorage/v1/volumes"
 	"github.com/rackspace/gophercloud/openstack/compute/v2/extensions/volumeattach"
 	"github.com/rackspace/gophercloud/openstack/compute/v2/flavors"
@@ -17,64 +19,114 @@ import (
 )
 
 // images
-func getImageID(client *gophercloud.ServiceClient, d *schema.ResourceData) (string, error) {
-	imageID := d.Get("image_id").(string)
+func getImageID(client *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}) (string, error) {
+	if imageID := d.Get("image_id").(string); imageID != "" {
+		return imageID, nil
+	}
+
+	if v, ok := d.GetOk("image"); ok {
+		lookup := v.([]interface{})[0].(map[string]interface{})
+		image, err := lookupImage(d, meta, lookup)
+		if err != nil {
+			return "", err
+		}
+
+		log.Printf("[INFO] Image lookup found: %s (%s)", image.Name, image.Id)
+		return image.Id, nil
+	}
+
 	imageName := d.Get("image_name").(string)
-	if imageID == "" {
-		pager := images.ListDetail(client, nil)
+	if imageName == "" {
+		return "", fmt.Errorf("Neither an image ID nor an image name were able to be determined.")
+	}
 
-		pager.EachPage(func(page pagination.Page) (bool, error) {
-			imageList, err := images.ExtractImages(page)
+	imageList, err := listImages(client, d, meta)
+	if err != nil {
+		return "", err
+	}
+
+	var imageIDs []string
+	for _, i := range imageList {
+		if i.Name == imageName {
+			imageIDs = append(imageIDs, i.ID)
+		}
+	}
 
+	switch len(imageIDs) {
+	case 0:
+		return "", fmt.Errorf("Unable to find image: %v", imageName)
+	case 1:
+		return imageIDs[0], nil
+	}
+
+	return "", fmt.Errorf(
+		"%d images are named %v, use image_id or an image block with most_recent: %v",
+		len(imageIDs), imageName, strings.Join(imageIDs, ", "))
+}
+
+func listImages(client *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}) ([]images.Image, error) {
+	v, err := cachedLookup(d, meta, "image", "list", func() (interface{}, error) {
+		var imageList []images.Image
+		err := images.ListDetail(client, nil).EachPage(func(page pagination.Page) (bool, error) {
+			is, err := images.ExtractImages(page)
 			if err != nil {
 				return false, err
 			}
 
-			for _, i := range imageList {
-				if i.Name == imageName {
-					imageID = i.ID
-				}
-			}
+			imageList = append(imageList, is...)
 			return true, nil
 		})
 
-		if imageID == "" {
-			return "", fmt.Errorf("Unable to find image: %v", imageName)
-		}
-	}
+		return imageList, err
+	})
 
-	if imageID == "" && imageName == "" {
-		return "", fmt.Errorf("Neither an image ID nor an image name were able to be determined.")
+	if err != nil {
+		return nil, err
 	}
 
-	return imageID, nil
+	return v.([]images.Image), nil
 }
 
-func getImage(client *gophercloud.ServiceClient, imageId string) (*images.Image, error) {
-	return images.Get(client, imageId).Extract()
+func getImage(client *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}, imageId string) (*images.Image, error) {
+	v, err := cachedLookup(d, meta, "image", imageId, func() (interface{}, error) {
+		return images.Get(client, imageId).Extract()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*images.Image), nil
 }
 
 // flavors
-func getFlavorID(client *gophercloud.ServiceClient, d *schema.ResourceData) (string, error) {
+func getFlavorID(client *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}) (string, error) {
 	flavorID := d.Get("flavor_id").(string)
 	flavorName := d.Get("flavor_name").(string)
-	if flavorID == "" {
-		pager := flavors.ListDetail(client, nil)
-
-		pager.EachPage(func(page pagination.Page) (bool, error) {
-			flavorList, err := flavors.ExtractFlavors(page)
 
+	if flavorID == "" {
+		if v, ok := d.GetOk("flavor"); ok {
+			flavor, err := lookupFlavor(client, d, meta, v.([]interface{})[0].(map[string]interface{}))
 			if err != nil {
-				return false, err
+				return "", err
 			}
 
-			for _, f := range flavorList {
-				if f.Name == flavorName {
-					flavorID = f.ID
-				}
+			log.Printf("[INFO] Selected flavor %s (%s)", flavor.Name, flavor.ID)
+			return flavor.ID, nil
+		}
+	}
+
+	if flavorID == "" {
+		flavorList, err := listFlavors(client, d, meta)
+		if err != nil {
+			return "", err
+		}
+
+		for _, f := range flavorList {
+			if f.Name == flavorName {
+				flavorID = f.ID
 			}
-			return true, nil
-		})
+		}
 
 		if flavorID == "" {
 			return "", fmt.Errorf("Unable to find flavor: %v", flavorName)
@@ -88,8 +140,39 @@ func getFlavorID(client *gophercloud.ServiceClient, d *schema.ResourceData) (str
 	return flavorID, nil
 }
 
-func getFlavor(client *gophercloud.ServiceClient, flavorId string) (*flavors.Flavor, error) {
-	return flavors.Get(client, flavorId).Extract()
+func listFlavors(client *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}) ([]flavors.Flavor, error) {
+	v, err := cachedLookup(d, meta, "flavor", "list", func() (interface{}, error) {
+		var flavorList []flavors.Flavor
+		err := flavors.ListDetail(client, nil).EachPage(func(page pagination.Page) (bool, error) {
+			fs, err := flavors.ExtractFlavors(page)
+			if err != nil {
+				return false, err
+			}
+
+			flavorList = append(flavorList, fs...)
+			return true, nil
+		})
+
+		return flavorList, err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]flavors.Flavor), nil
+}
+
+func getFlavor(client *gophercloud.ServiceClient, d *schema.ResourceData, meta interface{}, flavorId string) (*flavors.Flavor, error) {
+	v, err := cachedLookup(d, meta, "flavor", flavorId, func() (interface{}, error) {
+		return flavors.Get(client, flavorId).Extract()
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return v.(*flavors.Flavor), nil
 }
 
 // volumes
@@ -109,6 +192,77 @@ func waitForVolumeState(client *gophercloud.ServiceClient, volumeId string) reso
 	}
 }
 
+// waitForVolumeRetype reports a volume as migrating while its data is
+// being moved to another backend, and as retyping while cinder changes
+// its type in place.
+func waitForVolumeRetype(client *gophercloud.ServiceClient, volumeId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		latest, err := getVolumeMigration(client, volumeId)
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Printf("Volume status: %v, migration status: %v", latest.Status, latest.MigrationStatus)
+
+		switch latest.MigrationStatus {
+		case "starting", "migrating", "completing":
+			return latest, "migrating", nil
+		case "error":
+			return latest, latest.MigrationStatus, fmt.Errorf(
+				"Volume %s failed to migrate to volume type %s.", volumeId, latest.VolumeType)
+		}
+
+		return latest, latest.Status, nil
+	}
+}
+
+// snapshots
+func waitForSnapshotState(client *gophercloud.ServiceClient, snapshotId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		latest, err := snapshots.Get(client, snapshotId).Extract()
+		if err != nil {
+			if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+				return "", "DELETED", nil
+			}
+			return nil, "", err
+		}
+
+		log.Printf("Snapshot status: %v", latest.Status)
+
+		switch latest.Status {
+		case "error":
+			return latest, latest.Status, fmt.Errorf("Snapshot %s went into the error state.", snapshotId)
+		case "error_deleting":
+			return latest, latest.Status, fmt.Errorf(
+				"Snapshot %s could not be deleted and is in error_deleting. Reset its state with `cinder snapshot-reset-state` and try again.", snapshotId)
+		}
+
+		return latest, latest.Status, nil
+	}
+}
+
+// backups
+func waitForVolumeBackupState(client *gophercloud.ServiceClient, backupId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		latest, err := getVolumeBackup(client, backupId)
+		if err != nil {
+			if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+				return "", "DELETED", nil
+			}
+			return nil, "", err
+		}
+
+		log.Printf("Backup status: %v", latest.Status)
+
+		if latest.Status == "error" || latest.Status == "error_restoring" {
+			return latest, latest.Status, fmt.Errorf(
+				"Backup %s went into the %s state: %s", backupId, latest.Status, latest.FailReason)
+		}
+
+		return latest, latest.Status, nil
+	}
+}
+
 func attachVolumes(computeClient *gophercloud.ServiceClient, blockClient *gophercloud.ServiceClient, serverId string, vols []interface{}) error {
 	if len(vols) > 0 {
 		for _, v := range vols {
@@ -136,7 +290,7 @@ func attachVolumes(computeClient *gophercloud.ServiceClient, blockClient *gopher
 
 			stateConf := &resource.StateChangeConf{
 				Pending:    []string{"available", "attaching"},
-				Target:     "in-use",
+				Target:     []string{"in-use"},
 				Refresh:    waitForVolumeState(blockClient, va["volume_id"].(string)),
 				Timeout:    30 * time.Minute,
 				Delay:      5 * time.Second,
@@ -172,7 +326,7 @@ func detachVolumes(computeClient *gophercloud.ServiceClient, blockClient *gopher
 
 			stateConf := &resource.StateChangeConf{
 				Pending:    []string{"in-use", "detaching"},
-				Target:     "available",
+				Target:     []string{"available"},
 				Refresh:    waitForVolumeState(blockClient, va["volume_id"].(string)),
 				Timeout:    30 * time.Minute,
 				Delay:      5 * time.Second,
@@ -0,0 +1,219 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+)
+
+type GlanceImage struct {
+	Id         string
+	Name       string
+	Visibility string
+	Status     string
+	CreatedAt  string
+	Properties map[string]string
+}
+
+// attributes of a glance v2 image that aren't custom properties
+var glanceImageAttributes = map[string]bool{
+	"id": true, "name": true, "visibility": true, "status": true,
+	"created_at": true, "updated_at": true, "checksum": true,
+	"container_format": true, "disk_format": true, "min_disk": true,
+	"min_ram": true, "owner": true, "protected": true, "size": true,
+	"virtual_size": true, "tags": true, "file": true, "schema": true,
+	"self": true, "direct_url": true, "locations": true,
+}
+
+func listGlanceImages(client *gophercloud.ServiceClient, visibility string) ([]GlanceImage, error) {
+	var images []GlanceImage
+
+	url := client.ServiceURL("images") + "?status=active&limit=100"
+	if visibility != "" {
+		url += "&visibility=" + visibility
+	}
+
+	for url != "" {
+		var page struct {
+			Images []map[string]interface{} `json:"images"`
+			Next   string                   `json:"next"`
+		}
+
+		_, err := perigee.Request(
+			"GET",
+			url,
+			perigee.Options{
+				MoreHeaders: client.AuthenticatedHeaders(),
+				Results:     &page,
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range page.Images {
+			image := GlanceImage{
+				Properties: make(map[string]string),
+			}
+			image.Id, _ = i["id"].(string)
+			image.Name, _ = i["name"].(string)
+			image.Visibility, _ = i["visibility"].(string)
+			image.Status, _ = i["status"].(string)
+			image.CreatedAt, _ = i["created_at"].(string)
+
+			for k, v := range i {
+				if s, ok := v.(string); ok && !glanceImageAttributes[k] {
+					image.Properties[k] = s
+				}
+			}
+
+			images = append(images, image)
+		}
+
+		// next is a path such as /v2/images?marker=...
+		url = ""
+		if page.Next != "" {
+			url = client.Endpoint + strings.TrimPrefix(page.Next, "/")
+		}
+	}
+
+	log.Printf("[INFO] Found %d images", len(images))
+
+	return images, nil
+}
+
+// selectImage picks the one image that matches all of the filters.
+// If several match, the newest one is used when mostRecent is set.
+func selectImage(images []GlanceImage, name string, nameRegex *regexp.Regexp, properties map[string]string, mostRecent bool) (*GlanceImage, error) {
+	var matches []GlanceImage
+	for _, i := range images {
+		if name != "" && i.Name != name {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(i.Name) {
+			continue
+		}
+
+		match := true
+		for k, v := range properties {
+			if i.Properties[k] != v {
+				match = false
+				break
+			}
+		}
+
+		if match {
+			matches = append(matches, i)
+		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("No image matches the given filters.")
+	}
+
+	if len(matches) > 1 {
+		if !mostRecent {
+			var found []string
+			for _, i := range matches {
+				found = append(found, fmt.Sprintf("%s (%s)", i.Name, i.Id))
+			}
+			return nil, fmt.Errorf(
+				"%d images match the given filters, narrow them down or set most_recent: %s",
+				len(matches), strings.Join(found, ", "))
+		}
+
+		sort.Sort(imagesByCreatedAt(matches))
+	}
+
+	return &matches[len(matches)-1], nil
+}
+
+type imagesByCreatedAt []GlanceImage
+
+func (a imagesByCreatedAt) Len() int      { return len(a) }
+func (a imagesByCreatedAt) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a imagesByCreatedAt) Less(i, j int) bool {
+	ti, _ := time.Parse(time.RFC3339, a[i].CreatedAt)
+	tj, _ := time.Parse(time.RFC3339, a[j].CreatedAt)
+	return ti.Before(tj)
+}
+
+// lookupImage resolves an image block to a single image.
+func lookupImage(d *schema.ResourceData, meta interface{}, lookup map[string]interface{}) (*GlanceImage, error) {
+	client, err := getClient("image", d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	var nameRegex *regexp.Regexp
+	if v := lookup["name_regex"].(string); v != "" {
+		nameRegex, err = regexp.Compile(v)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid name_regex: %v", err)
+		}
+	}
+
+	properties := make(map[string]string)
+	if v, ok := lookup["properties"].(map[string]interface{}); ok {
+		for k, v := range v {
+			properties[k] = v.(string)
+		}
+	}
+
+	visibility := lookup["visibility"].(string)
+	v, err := cachedLookup(d, meta, "image", "glance/"+visibility, func() (interface{}, error) {
+		return listGlanceImages(client, visibility)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return selectImage(v.([]GlanceImage), lookup["name"].(string), nameRegex, properties, lookup["most_recent"].(bool))
+}
+
+// imageLookupSchema is the image block shared by instances and volumes.
+func imageLookupSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		ForceNew:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"image_id", "image_name"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"name": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					ForceNew: true,
+				},
+				"name_regex": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					ForceNew: true,
+				},
+				"properties": &schema.Schema{
+					Type:     schema.TypeMap,
+					Optional: true,
+					ForceNew: true,
+				},
+				"visibility": &schema.Schema{
+					Type:     schema.TypeString,
+					Optional: true,
+					ForceNew: true,
+				},
+				"most_recent": &schema.Schema{
+					Type:     schema.TypeBool,
+					Optional: true,
+					ForceNew: true,
+				},
+			},
+		},
+	}
+}
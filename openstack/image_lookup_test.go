@@ -0,0 +1,73 @@
+package openstack
+
+import (
+	"regexp"
+	"testing"
+)
+
+var testImages = []GlanceImage{
+	GlanceImage{
+		Id:         "1",
+		Name:       "Ubuntu 14.04 20150101",
+		CreatedAt:  "2015-01-01T00:00:00Z",
+		Properties: map[string]string{"os_distro": "ubuntu"},
+	},
+	GlanceImage{
+		Id:         "2",
+		Name:       "Ubuntu 14.04 20150301",
+		CreatedAt:  "2015-03-01T00:00:00Z",
+		Properties: map[string]string{"os_distro": "ubuntu"},
+	},
+	GlanceImage{
+		Id:         "3",
+		Name:       "Ubuntu 14.04 20150201",
+		CreatedAt:  "2015-02-01T00:00:00Z",
+		Properties: map[string]string{"os_distro": "ubuntu"},
+	},
+	GlanceImage{
+		Id:         "4",
+		Name:       "CentOS 7",
+		CreatedAt:  "2015-04-01T00:00:00Z",
+		Properties: map[string]string{"os_distro": "centos"},
+	},
+}
+
+func TestSelectImage(t *testing.T) {
+	ubuntu := regexp.MustCompile("^Ubuntu 14.04")
+
+	image, err := selectImage(testImages, "", ubuntu, nil, true)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if image.Id != "2" {
+		t.Fatalf("Expected the most recent image, got: %v", image.Id)
+	}
+
+	image, err = selectImage(testImages, "", nil, map[string]string{"os_distro": "centos"}, false)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if image.Id != "4" {
+		t.Fatalf("Expected the centos image, got: %v", image.Id)
+	}
+
+	image, err = selectImage(testImages, "Ubuntu 14.04 20150201", nil, nil, false)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if image.Id != "3" {
+		t.Fatalf("Expected the named image, got: %v", image.Id)
+	}
+}
+
+func TestSelectImage_ambiguous(t *testing.T) {
+	ubuntu := regexp.MustCompile("^Ubuntu 14.04")
+
+	if _, err := selectImage(testImages, "", ubuntu, nil, false); err == nil {
+		t.Fatal("Expected an error when several images match")
+	}
+
+	if _, err := selectImage(testImages, "", nil, map[string]string{"os_distro": "fedora"}, true); err == nil {
+		t.Fatal("Expected an error when no image matches")
+	}
+}
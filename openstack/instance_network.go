@@ -0,0 +1,178 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+)
+
+type InstanceInterface struct {
+	PortId    string                   `json:"port_id"`
+	NetId     string                   `json:"net_id"`
+	MacAddr   string                   `json:"mac_addr"`
+	PortState string                   `json:"port_state"`
+	FixedIps  []map[string]interface{} `json:"fixed_ips"`
+}
+
+// listInstanceInterfaces uses the os-interface extension to map an
+// instance's MAC addresses to Neutron ports and networks.
+// nova-network clouds do not support this extension.
+func listInstanceInterfaces(client *gophercloud.ServiceClient, instanceId string) ([]InstanceInterface, error) {
+	var ifaces []InstanceInterface
+	ep := fmt.Sprintf("servers/%s/os-interface", instanceId)
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL(ep),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				InstanceInterfaces *[]InstanceInterface `json:"interfaceAttachments"`
+			}{&ifaces},
+		},
+	)
+
+	log.Printf("[INFO] Instance Interfaces: %v", ifaces)
+
+	return ifaces, err
+}
+
+// getInstanceAddresses turns the addresses reported by nova into one
+// entry per port. Nova groups addresses by network label, so the MAC
+// address is used to tell multiple ports on the same network apart.
+func getInstanceAddresses(addresses map[string]interface{}) []map[string]interface{} {
+	var labels []string
+	for label := range addresses {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+
+	var nets []map[string]interface{}
+	for _, label := range labels {
+		var macs []string
+		ports := make(map[string]map[string]interface{})
+
+		for _, v := range addresses[label].([]interface{}) {
+			addr := v.(map[string]interface{})
+			mac, _ := addr["OS-EXT-IPS-MAC:mac_addr"].(string)
+
+			n, ok := ports[mac]
+			if !ok {
+				n = map[string]interface{}{
+					"name":        label,
+					"uuid":        "",
+					"port":        "",
+					"mac":         mac,
+					"fixed_ip":    "",
+					"fixed_ip_v4": "",
+					"fixed_ip_v6": "",
+					"floating_ip": "",
+				}
+				ports[mac] = n
+				macs = append(macs, mac)
+			}
+
+			ip, _ := addr["addr"].(string)
+			if t, _ := addr["OS-EXT-IPS:type"].(string); t == "floating" {
+				n["floating_ip"] = ip
+				continue
+			}
+
+			if addr["version"] == 4.0 && n["fixed_ip_v4"] == "" {
+				n["fixed_ip_v4"] = ip
+			} else if addr["version"] == 6.0 && n["fixed_ip_v6"] == "" {
+				n["fixed_ip_v6"] = ip
+			}
+		}
+
+		for _, mac := range macs {
+			nets = append(nets, ports[mac])
+		}
+	}
+
+	return nets
+}
+
+// getInstanceNetworks builds the structured network list of an instance.
+// Networks that were configured keep their position so the list lines up
+// with the network blocks. Other networks, such as interfaces attached
+// outside of Terraform, are left out, since network is ForceNew. When no
+// networks are configured, all of them are reported.
+func getInstanceNetworks(client *gophercloud.ServiceClient, serverId string, addresses map[string]interface{}, d *schema.ResourceData) []map[string]interface{} {
+	nets := getInstanceAddresses(addresses)
+
+	ifaces, err := listInstanceInterfaces(client, serverId)
+	if err != nil {
+		log.Printf("[INFO] Unable to list instance interfaces, assuming nova-network: %v", err)
+	}
+	for _, n := range nets {
+		for _, iface := range ifaces {
+			if iface.MacAddr == n["mac"] {
+				n["uuid"] = iface.NetId
+				n["port"] = iface.PortId
+			}
+		}
+	}
+
+	var configured []interface{}
+	if v, ok := d.Get("network").([]interface{}); ok {
+		configured = v
+	}
+
+	used := make([]bool, len(nets))
+	var result []map[string]interface{}
+	for _, c := range configured {
+		cn := c.(map[string]interface{})
+		match := -1
+		for i, n := range nets {
+			if used[i] {
+				continue
+			}
+			if port := cn["port"].(string); port != "" && port == n["port"] {
+				match = i
+				break
+			}
+			if uuid := cn["uuid"].(string); uuid != "" && uuid == n["uuid"] {
+				match = i
+				break
+			}
+			if name := cn["name"].(string); name != "" && name == n["name"] {
+				match = i
+				break
+			}
+		}
+
+		// nova-network does not report network UUIDs, so fall back
+		// to matching the networks in the order they were requested
+		if match == -1 {
+			for i, n := range nets {
+				if !used[i] && n["uuid"] == "" {
+					match = i
+					break
+				}
+			}
+		}
+
+		if match == -1 {
+			continue
+		}
+
+		used[match] = true
+		n := nets[match]
+		if n["uuid"] == "" {
+			n["uuid"] = cn["uuid"]
+		}
+		n["fixed_ip"] = cn["fixed_ip"]
+		result = append(result, n)
+	}
+
+	if len(configured) == 0 {
+		return nets
+	}
+
+	return result
+}
@@ -0,0 +1,165 @@
+package openstack
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/compute/v2/servers"
+)
+
+// getInstanceConsoleOutput returns the last `length` lines of an instance's
+// serial console. A length of 0 returns the entire log.
+func getInstanceConsoleOutput(client *gophercloud.ServiceClient, instanceId string, length int) (string, error) {
+	var output string
+	ep := fmt.Sprintf("servers/%s/action", instanceId)
+
+	opts := map[string]interface{}{}
+	if length > 0 {
+		opts["length"] = length
+	}
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL(ep),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"os-getConsoleOutput": opts,
+			},
+			Results: &struct {
+				Output *string `json:"output"`
+			}{&output},
+			OkCodes: []int{200},
+		},
+	)
+
+	return output, err
+}
+
+// appendConsoleOutput adds the tail of the console log to an error so
+// failed boots can be debugged without access to the cloud. The log is
+// also kept in console_output.
+func appendConsoleOutput(client *gophercloud.ServiceClient, instanceId string, d *schema.ResourceData, err error) error {
+	lines := d.Get("console_output_lines").(int)
+	if lines <= 0 {
+		return err
+	}
+
+	output, cerr := getInstanceConsoleOutput(client, instanceId, lines)
+	if cerr != nil || output == "" {
+		log.Printf("[INFO] Unable to get console output: %v", cerr)
+		return err
+	}
+
+	d.Set("console_output", output)
+
+	return fmt.Errorf("%v\n\nConsole output:\n%s", err, output)
+}
+
+// waitForInstanceReady blocks until every wait_for condition is met.
+// Each condition has its own timeout.
+func waitForInstanceReady(client *gophercloud.ServiceClient, instanceId string, waits []interface{}, d *schema.ResourceData) error {
+	for _, v := range waits {
+		w := v.(map[string]interface{})
+
+		timeout, err := time.ParseDuration(w["timeout"].(string))
+		if err != nil {
+			return fmt.Errorf("Invalid wait_for timeout: %v", err)
+		}
+
+		var refresh resource.StateRefreshFunc
+		port := w["port"].(int)
+		consoleLog := w["console_log"].(string)
+		metadataKey := w["metadata_key"].(string)
+
+		switch {
+		case port > 0 && consoleLog == "" && metadataKey == "":
+			host, err := getInstanceAccessAddress(d)
+			if err != nil {
+				return err
+			}
+			if host == "" {
+				return errors.New("Unable to determine an address to check the port of.")
+			}
+			refresh = waitForInstancePort(host, port)
+		case consoleLog != "" && port == 0 && metadataKey == "":
+			re, err := regexp.Compile(consoleLog)
+			if err != nil {
+				return fmt.Errorf("Invalid wait_for console_log: %v", err)
+			}
+			refresh = waitForInstanceConsoleLog(client, instanceId, re)
+		case metadataKey != "" && port == 0 && consoleLog == "":
+			refresh = waitForInstanceMetadata(client, instanceId, metadataKey)
+		default:
+			return errors.New("Exactly one of port, console_log or metadata_key must be set in each wait_for block.")
+		}
+
+		stateConf := &resource.StateChangeConf{
+			Pending:    []string{"waiting"},
+			Target:     []string{"ready"},
+			Refresh:    refresh,
+			Timeout:    timeout,
+			Delay:      10 * time.Second,
+			MinTimeout: 3 * time.Second,
+		}
+
+		if _, err := stateConf.WaitForState(); err != nil {
+			return fmt.Errorf("Error waiting for instance %s to become ready: %v", instanceId, err)
+		}
+	}
+
+	return nil
+}
+
+func waitForInstancePort(host string, port int) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		address := net.JoinHostPort(host, strconv.Itoa(port))
+		conn, err := net.DialTimeout("tcp", address, 5*time.Second)
+		if err != nil {
+			log.Printf("[INFO] Port %s is not reachable yet: %v", address, err)
+			return address, "waiting", nil
+		}
+		conn.Close()
+
+		return address, "ready", nil
+	}
+}
+
+func waitForInstanceConsoleLog(client *gophercloud.ServiceClient, instanceId string, re *regexp.Regexp) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		output, err := getInstanceConsoleOutput(client, instanceId, 0)
+		if err != nil {
+			return nil, "", err
+		}
+
+		if re.MatchString(output) {
+			return output, "ready", nil
+		}
+
+		return output, "waiting", nil
+	}
+}
+
+func waitForInstanceMetadata(client *gophercloud.ServiceClient, instanceId string, key string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		server, err := servers.Get(client, instanceId).Extract()
+		if err != nil {
+			return nil, "", err
+		}
+
+		if _, ok := server.Metadata[key]; ok {
+			return server, "ready", nil
+		}
+
+		return server, "waiting", nil
+	}
+}
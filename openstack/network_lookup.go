@@ -0,0 +1,141 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/networks"
+	"github.com/rackspace/gophercloud/pagination"
+)
+
+var uuidPattern = regexp.MustCompile("^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$")
+
+type NovaTenantNetwork struct {
+	Id    string `json:"id"`
+	Label string `json:"label"`
+	CIDR  string `json:"cidr"`
+}
+
+// lookupNetwork is a network name and ID, from either backend.
+type lookupNetwork struct {
+	Id   string
+	Name string
+}
+
+func isUUID(s string) bool {
+	return uuidPattern.MatchString(s)
+}
+
+func listNovaTenantNetworks(client *gophercloud.ServiceClient) ([]NovaTenantNetwork, error) {
+	var nets []NovaTenantNetwork
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("os-tenant-networks"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Networks *[]NovaTenantNetwork `json:"networks"`
+			}{&nets},
+		},
+	)
+
+	return nets, err
+}
+
+// listLookupNetworks lists the networks an instance can use. Neutron is
+// used when the cloud has a networking endpoint, os-tenant-networks
+// otherwise.
+func listLookupNetworks(d *schema.ResourceData, meta interface{}) ([]lookupNetwork, error) {
+	networkClient, err := getClient("network", d, meta)
+	if err != nil {
+		log.Printf("[INFO] Networking service not available, using os-tenant-networks: %v", err)
+
+		computeClient, err := getClient("compute", d, meta)
+		if err != nil {
+			return nil, err
+		}
+
+		v, err := cachedLookup(d, meta, "network", "nova", func() (interface{}, error) {
+			nets, err := listNovaTenantNetworks(computeClient)
+			if err != nil {
+				return nil, err
+			}
+
+			var result []lookupNetwork
+			for _, n := range nets {
+				result = append(result, lookupNetwork{Id: n.Id, Name: n.Label})
+			}
+			return result, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return v.([]lookupNetwork), nil
+	}
+
+	v, err := cachedLookup(d, meta, "network", "neutron", func() (interface{}, error) {
+		var result []lookupNetwork
+		err := networks.List(networkClient, networks.ListOpts{}).EachPage(func(page pagination.Page) (bool, error) {
+			nets, err := networks.ExtractNetworks(page)
+			if err != nil {
+				return false, err
+			}
+
+			for _, n := range nets {
+				result = append(result, lookupNetwork{Id: n.ID, Name: n.Name})
+			}
+			return true, nil
+		})
+
+		return result, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return v.([]lookupNetwork), nil
+}
+
+// selectNetwork returns the ID of the one network with the given name.
+func selectNetwork(nets []lookupNetwork, name string) (string, error) {
+	var ids []string
+	for _, n := range nets {
+		if n.Name == name {
+			ids = append(ids, n.Id)
+		}
+	}
+
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("Unable to find network: %s", name)
+	case 1:
+		return ids[0], nil
+	}
+
+	return "", fmt.Errorf(
+		"%d networks are named %s, use the network UUID instead: %s",
+		len(ids), name, strings.Join(ids, ", "))
+}
+
+// getNetworkID resolves a network name to its ID.
+func getNetworkID(d *schema.ResourceData, meta interface{}, name string) (string, error) {
+	nets, err := listLookupNetworks(d, meta)
+	if err != nil {
+		return "", err
+	}
+
+	id, err := selectNetwork(nets, name)
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("[INFO] Network %s resolved to %s", name, id)
+	return id, nil
+}
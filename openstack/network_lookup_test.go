@@ -0,0 +1,45 @@
+package openstack
+
+import (
+	"testing"
+)
+
+var testNetworks = []lookupNetwork{
+	lookupNetwork{Id: "3c7fa1e9-2ba0-4d7e-9a2e-5a3b8c0e6f41", Name: "private"},
+	lookupNetwork{Id: "9d0e1f2a-3b4c-4d5e-8f6a-7b8c9d0e1f2a", Name: "public"},
+	lookupNetwork{Id: "5e6f7a8b-9c0d-4e1f-a2b3-c4d5e6f7a8b9", Name: "public"},
+}
+
+func TestSelectNetwork(t *testing.T) {
+	id, err := selectNetwork(testNetworks, "private")
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if id != "3c7fa1e9-2ba0-4d7e-9a2e-5a3b8c0e6f41" {
+		t.Fatalf("Expected the private network, got: %v", id)
+	}
+
+	if _, err := selectNetwork(testNetworks, "public"); err == nil {
+		t.Fatal("Expected an error when several networks match")
+	}
+
+	if _, err := selectNetwork(testNetworks, "management"); err == nil {
+		t.Fatal("Expected an error when no network matches")
+	}
+}
+
+func TestIsUUID(t *testing.T) {
+	valid := []string{"3c7fa1e9-2ba0-4d7e-9a2e-5a3b8c0e6f41", "3C7FA1E9-2BA0-4D7E-9A2E-5A3B8C0E6F41"}
+	for _, v := range valid {
+		if !isUUID(v) {
+			t.Fatalf("%q should be a UUID", v)
+		}
+	}
+
+	invalid := []string{"private", "3c7fa1e9-2ba0-4d7e-9a2e", "3c7fa1e92ba04d7e9a2e5a3b8c0e6f41"}
+	for _, v := range invalid {
+		if isUUID(v) {
+			t.Fatalf("%q should not be a UUID", v)
+		}
+	}
+}
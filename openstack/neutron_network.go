@@ -0,0 +1,188 @@
+package openstack
+
+import (
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+)
+
+// gophercloud's networks and subnets packages don't cover the provider,
+// external and mtu network attributes or the ipv6 subnet modes, so
+// networks and subnets are managed with raw requests.
+
+type NeutronNetworkSegment struct {
+	NetworkType     string `json:"provider:network_type,omitempty"`
+	PhysicalNetwork string `json:"provider:physical_network,omitempty"`
+	SegmentationId  int    `json:"provider:segmentation_id,omitempty"`
+}
+
+type NeutronNetwork struct {
+	Id              string                  `json:"id"`
+	Name            string                  `json:"name"`
+	AdminStateUp    bool                    `json:"admin_state_up"`
+	Shared          bool                    `json:"shared"`
+	External        bool                    `json:"router:external"`
+	MTU             int                     `json:"mtu"`
+	Status          string                  `json:"status"`
+	Subnets         []string                `json:"subnets"`
+	TenantId        string                  `json:"tenant_id"`
+	NetworkType     string                  `json:"provider:network_type"`
+	PhysicalNetwork string                  `json:"provider:physical_network"`
+	SegmentationId  int                     `json:"provider:segmentation_id"`
+	Segments        []NeutronNetworkSegment `json:"segments"`
+}
+
+type NeutronAllocationPool struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+type NeutronHostRoute struct {
+	DestinationCIDR string `json:"destination"`
+	NextHop         string `json:"nexthop"`
+}
+
+type NeutronSubnet struct {
+	Id              string                  `json:"id"`
+	NetworkId       string                  `json:"network_id"`
+	Name            string                  `json:"name"`
+	CIDR            string                  `json:"cidr"`
+	IPVersion       int                     `json:"ip_version"`
+	GatewayIP       *string                 `json:"gateway_ip"`
+	AllocationPools []NeutronAllocationPool `json:"allocation_pools"`
+	DNSNameservers  []string                `json:"dns_nameservers"`
+	HostRoutes      []NeutronHostRoute      `json:"host_routes"`
+	EnableDHCP      bool                    `json:"enable_dhcp"`
+	IPv6AddressMode string                  `json:"ipv6_address_mode"`
+	IPv6RAMode      string                  `json:"ipv6_ra_mode"`
+	TenantId        string                  `json:"tenant_id"`
+}
+
+// getNetworkSegments returns the segments of a network, whether neutron
+// reports them through the provider or the multi-provider extension.
+func getNetworkSegments(n *NeutronNetwork) []NeutronNetworkSegment {
+	if len(n.Segments) > 0 {
+		return n.Segments
+	}
+
+	if n.NetworkType != "" {
+		return []NeutronNetworkSegment{
+			NeutronNetworkSegment{
+				NetworkType:     n.NetworkType,
+				PhysicalNetwork: n.PhysicalNetwork,
+				SegmentationId:  n.SegmentationId,
+			},
+		}
+	}
+
+	return nil
+}
+
+func createNeutronNetwork(client *gophercloud.ServiceClient, opts map[string]interface{}) (*NeutronNetwork, error) {
+	var network NeutronNetwork
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("networks"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"network": opts,
+			},
+			Results: &struct {
+				Network *NeutronNetwork `json:"network"`
+			}{&network},
+			OkCodes: []int{201},
+		},
+	)
+
+	return &network, err
+}
+
+func getNeutronNetwork(client *gophercloud.ServiceClient, networkId string) (*NeutronNetwork, error) {
+	var network NeutronNetwork
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("networks", networkId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Network *NeutronNetwork `json:"network"`
+			}{&network},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &network, err
+}
+
+func updateNeutronNetwork(client *gophercloud.ServiceClient, networkId string, opts map[string]interface{}) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("networks", networkId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"network": opts,
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
+
+func createNeutronSubnet(client *gophercloud.ServiceClient, opts map[string]interface{}) (*NeutronSubnet, error) {
+	var subnet NeutronSubnet
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("subnets"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"subnet": opts,
+			},
+			Results: &struct {
+				Subnet *NeutronSubnet `json:"subnet"`
+			}{&subnet},
+			OkCodes: []int{201},
+		},
+	)
+
+	return &subnet, err
+}
+
+func getNeutronSubnet(client *gophercloud.ServiceClient, subnetId string) (*NeutronSubnet, error) {
+	var subnet NeutronSubnet
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("subnets", subnetId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Subnet *NeutronSubnet `json:"subnet"`
+			}{&subnet},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &subnet, err
+}
+
+func updateNeutronSubnet(client *gophercloud.ServiceClient, subnetId string, opts map[string]interface{}) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("subnets", subnetId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"subnet": opts,
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
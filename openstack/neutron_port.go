@@ -0,0 +1,86 @@
+package openstack
+
+import (
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+)
+
+// gophercloud's ports package doesn't cover allowed address pairs, port
+// security or port bindings, so ports are managed with raw requests.
+
+type NeutronAddressPair struct {
+	IPAddress  string `json:"ip_address"`
+	MACAddress string `json:"mac_address,omitempty"`
+}
+
+type NeutronPort struct {
+	Id                  string               `json:"id"`
+	NetworkId           string               `json:"network_id"`
+	Name                string               `json:"name"`
+	AdminStateUp        bool                 `json:"admin_state_up"`
+	Status              string               `json:"status"`
+	MACAddress          string               `json:"mac_address"`
+	FixedIPs            []NeutronFixedIP     `json:"fixed_ips"`
+	SecurityGroups      []string             `json:"security_groups"`
+	AllowedAddressPairs []NeutronAddressPair `json:"allowed_address_pairs"`
+	PortSecurityEnabled *bool                `json:"port_security_enabled"`
+	VNICType            string               `json:"binding:vnic_type"`
+	DeviceOwner         string               `json:"device_owner"`
+	DeviceId            string               `json:"device_id"`
+	TenantId            string               `json:"tenant_id"`
+}
+
+func createNeutronPort(client *gophercloud.ServiceClient, opts map[string]interface{}) (*NeutronPort, error) {
+	var port NeutronPort
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("ports"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"port": opts,
+			},
+			Results: &struct {
+				Port *NeutronPort `json:"port"`
+			}{&port},
+			OkCodes: []int{201},
+		},
+	)
+
+	return &port, err
+}
+
+func getNeutronPort(client *gophercloud.ServiceClient, portId string) (*NeutronPort, error) {
+	var port NeutronPort
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("ports", portId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Port *NeutronPort `json:"port"`
+			}{&port},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &port, err
+}
+
+func updateNeutronPort(client *gophercloud.ServiceClient, portId string, opts map[string]interface{}) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("ports", portId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"port": opts,
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
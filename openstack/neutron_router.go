@@ -0,0 +1,205 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/ports"
+)
+
+// gophercloud's routers package doesn't cover the distributed and ha
+// flags, the external fixed IPs or enable_snat, so routers and their
+// interfaces are managed with raw requests like networks and subnets.
+
+type NeutronFixedIP struct {
+	SubnetId  string `json:"subnet_id,omitempty"`
+	IPAddress string `json:"ip_address,omitempty"`
+}
+
+type NeutronGatewayInfo struct {
+	NetworkId        string           `json:"network_id"`
+	EnableSNAT       *bool            `json:"enable_snat,omitempty"`
+	ExternalFixedIPs []NeutronFixedIP `json:"external_fixed_ips,omitempty"`
+}
+
+type NeutronRouter struct {
+	Id                  string              `json:"id"`
+	Name                string              `json:"name"`
+	AdminStateUp        bool                `json:"admin_state_up"`
+	Status              string              `json:"status"`
+	TenantId            string              `json:"tenant_id"`
+	Distributed         bool                `json:"distributed"`
+	HA                  bool                `json:"ha"`
+	ExternalGatewayInfo *NeutronGatewayInfo `json:"external_gateway_info"`
+	Routes              []NeutronHostRoute  `json:"routes"`
+}
+
+type NeutronRouterInterface struct {
+	Id       string `json:"id"`
+	SubnetId string `json:"subnet_id"`
+	PortId   string `json:"port_id"`
+}
+
+type NeutronL3Agent struct {
+	Id    string `json:"id"`
+	Host  string `json:"host"`
+	Alive bool   `json:"alive"`
+}
+
+type NeutronFloatingIP struct {
+	Id                string `json:"id"`
+	FloatingIPAddress string `json:"floating_ip_address"`
+	FixedIPAddress    string `json:"fixed_ip_address"`
+	PortId            string `json:"port_id"`
+	RouterId          string `json:"router_id"`
+}
+
+func createNeutronRouter(client *gophercloud.ServiceClient, opts map[string]interface{}) (*NeutronRouter, error) {
+	var router NeutronRouter
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("routers"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"router": opts,
+			},
+			Results: &struct {
+				Router *NeutronRouter `json:"router"`
+			}{&router},
+			OkCodes: []int{201},
+		},
+	)
+
+	return &router, err
+}
+
+func getNeutronRouter(client *gophercloud.ServiceClient, routerId string) (*NeutronRouter, error) {
+	var router NeutronRouter
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("routers", routerId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Router *NeutronRouter `json:"router"`
+			}{&router},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &router, err
+}
+
+func updateNeutronRouter(client *gophercloud.ServiceClient, routerId string, opts map[string]interface{}) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("routers", routerId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"router": opts,
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
+
+// updateNeutronRouterInterface adds or removes a router interface, with
+// action being either add_router_interface or remove_router_interface.
+func updateNeutronRouterInterface(client *gophercloud.ServiceClient, routerId, action string, opts map[string]string) (*NeutronRouterInterface, error) {
+	var iface NeutronRouterInterface
+
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("routers", routerId, action),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody:     opts,
+			Results:     &iface,
+			OkCodes:     []int{200},
+		},
+	)
+
+	return &iface, err
+}
+
+// getNeutronRouterL3Agents returns the L3 agents hosting a router. This is
+// admin-only with the default Neutron policy.
+func getNeutronRouterL3Agents(client *gophercloud.ServiceClient, routerId string) ([]NeutronL3Agent, error) {
+	var agents []NeutronL3Agent
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("routers", routerId, "l3-agents"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Agents *[]NeutronL3Agent `json:"agents"`
+			}{&agents},
+			OkCodes: []int{200},
+		},
+	)
+
+	return agents, err
+}
+
+// getRouterFloatingIPs returns the floating IPs that are associated
+// through a router.
+func getRouterFloatingIPs(client *gophercloud.ServiceClient, routerId string) ([]NeutronFloatingIP, error) {
+	var fips []NeutronFloatingIP
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("floatingips")+"?router_id="+routerId,
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				FloatingIPs *[]NeutronFloatingIP `json:"floatingips"`
+			}{&fips},
+			OkCodes: []int{200},
+		},
+	)
+
+	var used []NeutronFloatingIP
+	for _, fip := range fips {
+		if fip.PortId != "" {
+			used = append(used, fip)
+		}
+	}
+
+	return used, err
+}
+
+// waitForPortState reports the status of a neutron port, or DELETED once
+// it is gone.
+func waitForPortState(client *gophercloud.ServiceClient, portId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		port, err := ports.Get(client, portId).Extract()
+		if err != nil {
+			if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+				return "", "DELETED", nil
+			}
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] Port %s status: %s", portId, port.Status)
+		return port, port.Status, nil
+	}
+}
+
+func formatFloatingIPs(fips []NeutronFloatingIP) []string {
+	var s []string
+	for _, fip := range fips {
+		s = append(s, fmt.Sprintf("%s (fixed IP %s)", fip.FloatingIPAddress, fip.FixedIPAddress))
+	}
+
+	return s
+}
@@ -0,0 +1,183 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/rackspace/gophercloud/pagination"
+)
+
+func createNeutronSecgroup(client *gophercloud.ServiceClient, d *schema.ResourceData) (*groups.SecGroup, error) {
+	opts := groups.CreateOpts{
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+	}
+
+	return groups.Create(client, opts).Extract()
+}
+
+// updateNeutronSecgroup renames a security group or changes its description.
+func updateNeutronSecgroup(client *gophercloud.ServiceClient, sgID, name, description string) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("security-groups", sgID),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string](map[string]string){
+				"security_group": map[string]string{
+					"name":        name,
+					"description": description,
+				},
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
+
+func createNeutronSecgroupRule(client *gophercloud.ServiceClient, sgID string, rule map[string]interface{}) (*rules.SecGroupRule, error) {
+	protocol := rule["protocol"].(string)
+	fromPort := rule["from_port"].(int)
+	toPort := rule["to_port"].(int)
+
+	// nova uses -1 as a wildcard for icmp types and codes,
+	// neutron leaves them unset instead
+	if fromPort < 0 {
+		fromPort = 0
+	}
+	if toPort < 0 {
+		toPort = 0
+	}
+
+	opts := rules.CreateOpts{
+		Direction:    rule["direction"].(string),
+		EtherType:    getSecgroupRuleEtherType(rule),
+		SecGroupID:   sgID,
+		PortRangeMin: fromPort,
+		PortRangeMax: toPort,
+		Protocol:     protocol,
+	}
+
+	if rule["cidr"].(string) != "" {
+		opts.RemoteIPPrefix = rule["cidr"].(string)
+	} else if rule["source_group"].(string) != "" {
+		opts.RemoteGroupID = rule["source_group"].(string)
+	} else {
+		return nil, fmt.Errorf("At least one of cidr or source_group must be used.")
+	}
+
+	return rules.Create(client, opts).Extract()
+}
+
+// deleteNeutronSecgroupDefaultRules removes the egress rules neutron adds
+// to every new security group, so egress can be managed explicitly.
+func deleteNeutronSecgroupDefaultRules(client *gophercloud.ServiceClient, sgID string) error {
+	var ruleIDs []string
+	err := rules.List(client, rules.ListOpts{SecGroupID: sgID}).EachPage(func(page pagination.Page) (bool, error) {
+		ruleList, err := rules.ExtractRules(page)
+		if err != nil {
+			return false, err
+		}
+
+		for _, r := range ruleList {
+			if r.Direction == "egress" {
+				ruleIDs = append(ruleIDs, r.ID)
+			}
+		}
+		return true, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	for _, id := range ruleIDs {
+		log.Printf("[INFO] Deleting default egress rule: %v", id)
+		if err := rules.Delete(client, id).ExtractErr(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setNeutronSecgroupDetails(client *gophercloud.ServiceClient, sID string, d *schema.ResourceData) error {
+	sg, err := groups.Get(client, sID).Extract()
+	if err != nil {
+		return err
+	}
+	log.Printf("[INFO] Security Group info: %v", sg)
+
+	d.Set("name", sg.Name)
+	d.Set("id", sg.ID)
+	d.Set("description", sg.Description)
+	d.Set("tenant_id", sg.TenantID)
+
+	var sgRules []interface{}
+	for _, r := range sg.Rules {
+		// skip the default egress rules unless they're managed
+		if isNeutronSecgroupDefaultRule(r) && getSecgroupRuleState(d, r.ID) == nil {
+			continue
+		}
+
+		rule := map[string]interface{}{
+			"id":              r.ID,
+			"from_port":       r.PortRangeMin,
+			"to_port":         r.PortRangeMax,
+			"protocol":        r.Protocol,
+			"cidr":            r.RemoteIPPrefix,
+			"source_group":    r.RemoteGroupID,
+			"parent_group_id": r.SecGroupID,
+			"direction":       r.Direction,
+			"ethertype":       r.EtherType,
+		}
+
+		// keep the configured form of values neutron normalizes,
+		// so they don't show up as a diff
+		if old := getSecgroupRuleState(d, r.ID); old != nil {
+			if old["ethertype"].(string) == "" && getSecgroupRuleEtherType(old) == r.EtherType {
+				rule["ethertype"] = ""
+			}
+			if old["from_port"].(int) == -1 && r.PortRangeMin == 0 {
+				rule["from_port"] = -1
+			}
+			if old["to_port"].(int) == -1 && r.PortRangeMax == 0 {
+				rule["to_port"] = -1
+			}
+		}
+
+		sgRules = append(sgRules, rule)
+	}
+	log.Printf("[INFO] Security Group rules: %v", sgRules)
+	d.Set("rule", sgRules)
+
+	return nil
+}
+
+// isNeutronSecgroupDefaultRule reports whether a rule looks like one of the
+// allow-all egress rules neutron creates with every security group.
+func isNeutronSecgroupDefaultRule(r rules.SecGroupRule) bool {
+	return r.Direction == "egress" && r.Protocol == "" && r.PortRangeMin == 0 &&
+		r.PortRangeMax == 0 && r.RemoteIPPrefix == "" && r.RemoteGroupID == ""
+}
+
+// getSecgroupRuleEtherType returns the ethertype of a rule, falling back
+// to the address family of the rule's cidr.
+func getSecgroupRuleEtherType(rule map[string]interface{}) string {
+	if etherType := rule["ethertype"].(string); etherType != "" {
+		return etherType
+	}
+
+	if strings.Contains(rule["cidr"].(string), ":") {
+		return "IPv6"
+	}
+
+	return "IPv4"
+}
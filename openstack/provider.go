@@ -9,7 +9,7 @@ import (
 )
 
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	return &openstackProvider{&schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"identity_endpoint": &schema.Schema{
 				Type:        schema.TypeString,
@@ -71,6 +71,12 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 			},
 
+			"image_api_version": &schema.Schema{
+				Type:        schema.TypeString,
+				DefaultFunc: envDefaultFunc("OS_IMAGE_API_VERSION"),
+				Optional:    true,
+			},
+
 			"networking_api_version": &schema.Schema{
 				Type:        schema.TypeString,
 				DefaultFunc: envDefaultFunc("OS_NETWORK_API_VERSION"),
@@ -85,15 +91,47 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"openstack_instance":    resourceInstance(),
-			"openstack_keypair":     resourceKeypair(),
-			"openstack_floating_ip": resourceFloatingIP(),
-			"openstack_secgroup":    resourceSecgroup(),
-			"openstack_volume":      resourceVolume(),
+			"openstack_instance":         resourceInstance(),
+			"openstack_keypair":          resourceKeypair(),
+			"openstack_network":          resourceNetwork(),
+			"openstack_floating_ip":      resourceFloatingIP(),
+			"openstack_port":             resourcePort(),
+			"openstack_router":           resourceRouter(),
+			"openstack_router_interface": resourceRouterInterface(),
+			"openstack_secgroup":         resourceSecgroup(),
+			"openstack_secgroup_rule":    resourceSecgroupRule(),
+			"openstack_subnet":           resourceSubnet(),
+			"openstack_volume":           resourceVolume(),
+			"openstack_volume_backup":    resourceVolumeBackup(),
+			"openstack_volume_image":     resourceVolumeImage(),
+			"openstack_volume_qos":       resourceVolumeQoS(),
+			"openstack_volume_snapshot":  resourceVolumeSnapshot(),
+			"openstack_volume_type":      resourceVolumeType(),
 		},
 
 		ConfigureFunc: configureProvider,
+	}}
+}
+
+// openstackProvider validates combinations of resource arguments during
+// plan. helper/schema only validates one argument at a time.
+type openstackProvider struct {
+	*schema.Provider
+}
+
+func (p *openstackProvider) ValidateResource(t string, c *terraform.ResourceConfig) ([]string, []error) {
+	ws, es := p.Provider.ValidateResource(t, c)
+
+	switch t {
+	case "openstack_secgroup":
+		es = append(es, validateSecgroupRuleConfigs(c)...)
+	case "openstack_secgroup_rule":
+		if err := validateSecgroupRuleConfig(c, ""); err != nil {
+			es = append(es, err)
+		}
 	}
+
+	return ws, es
 }
 
 func envDefaultFunc(k string) schema.SchemaDefaultFunc {
@@ -108,6 +146,8 @@ func envDefaultFunc(k string) schema.SchemaDefaultFunc {
 			return "1", nil
 		case "OS_COMPUTE_API_VERSION":
 			return "2", nil
+		case "OS_IMAGE_API_VERSION":
+			return "2", nil
 		case "OS_NETWORK_API_VERSION":
 			return "2", nil
 		case "OS_OBJECT_API_VERSION":
@@ -144,6 +184,7 @@ func configureProvider(d *schema.ResourceData) (interface{}, error) {
 		DomainName:              d.Get("domain_name").(string),
 		BlockStorageAPIVersion:  d.Get("block_storage_api_version").(string),
 		ComputeAPIVersion:       d.Get("compute_api_version").(string),
+		ImageAPIVersion:         d.Get("image_api_version").(string),
 		NetworkingAPIVersion:    d.Get("networking_api_version").(string),
 		ObjectStorageAPIVersion: d.Get("object_storage_api_version").(string),
 	}
@@ -4,26 +4,31 @@ import (
 	"os"
 	"testing"
 
+	"github.com/hashicorp/terraform/config"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 )
 
 var (
 	OS_REGION_NAME = ""
+	OS_IMAGE_ID    = os.Getenv("OS_IMAGE_ID")
+	OS_FLAVOR_ID   = os.Getenv("OS_FLAVOR_ID")
+	OS_POOL_NAME   = os.Getenv("OS_POOL_NAME")
 )
 
 var testAccProviders map[string]terraform.ResourceProvider
 var testAccProvider *schema.Provider
 
 func init() {
-	testAccProvider = Provider().(*schema.Provider)
+	provider := Provider()
+	testAccProvider = provider.(*openstackProvider).Provider
 	testAccProviders = map[string]terraform.ResourceProvider{
-		"openstack": testAccProvider,
+		"openstack": provider,
 	}
 }
 
 func TestProvider(t *testing.T) {
-	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+	if err := Provider().(*openstackProvider).InternalValidate(); err != nil {
 		t.Fatalf("err: %s", err)
 	}
 }
@@ -32,6 +37,98 @@ func TestProvider_impl(t *testing.T) {
 	var _ terraform.ResourceProvider = Provider()
 }
 
+func TestProvider_validateSecgroupRules(t *testing.T) {
+	rule := func(protocol string, from, to int, cidr, sourceGroup string) map[string]interface{} {
+		r := map[string]interface{}{
+			"protocol":  protocol,
+			"from_port": from,
+			"to_port":   to,
+		}
+		if cidr != "" {
+			r["cidr"] = cidr
+		}
+		if sourceGroup != "" {
+			r["source_group"] = sourceGroup
+		}
+		return r
+	}
+
+	cases := []struct {
+		Type   string
+		Config map[string]interface{}
+		Err    bool
+	}{
+		{
+			Type: "openstack_secgroup",
+			Config: map[string]interface{}{
+				"name":        "web",
+				"description": "web",
+				"rule": []map[string]interface{}{
+					rule("tcp", 22, 22, "0.0.0.0/0", ""),
+					rule("icmp", -1, -1, "", "${openstack_secgroup.db.id}"),
+				},
+			},
+			Err: false,
+		},
+		{
+			Type: "openstack_secgroup",
+			Config: map[string]interface{}{
+				"name":        "web",
+				"description": "web",
+				"rule": []map[string]interface{}{
+					rule("tcp", 443, 80, "0.0.0.0/0", ""),
+				},
+			},
+			Err: true,
+		},
+		{
+			Type: "openstack_secgroup",
+			Config: map[string]interface{}{
+				"name":        "web",
+				"description": "web",
+				"rule": []map[string]interface{}{
+					rule("tcp", 22, 22, "", ""),
+				},
+			},
+			Err: true,
+		},
+		{
+			Type: "openstack_secgroup_rule",
+			Config: map[string]interface{}{
+				"security_group_id": "${openstack_secgroup.web.id}",
+				"protocol":          "tcp",
+				"from_port":         22,
+				"to_port":           22,
+				"source_group":      "${openstack_secgroup.db.id}",
+			},
+			Err: false,
+		},
+		{
+			Type: "openstack_secgroup_rule",
+			Config: map[string]interface{}{
+				"security_group_id": "${openstack_secgroup.web.id}",
+				"protocol":          "icmp",
+				"from_port":         -1,
+				"to_port":           0,
+				"source_group":      "${openstack_secgroup.db.id}",
+			},
+			Err: true,
+		},
+	}
+
+	for i, tc := range cases {
+		c, err := config.NewRawConfig(tc.Config)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+
+		_, es := Provider().ValidateResource(tc.Type, terraform.NewResourceConfig(c))
+		if len(es) > 0 != tc.Err {
+			t.Fatalf("%d: %#v", i, es)
+		}
+	}
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("OS_AUTH_URL"); v == "" {
 		t.Fatal("OS_AUTH_URL must be set for acceptance tests")
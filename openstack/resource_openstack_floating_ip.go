@@ -1,7 +1,9 @@
 package openstack
 
 import (
+	"fmt"
 	"log"
+	"net"
 	"strconv"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -13,6 +15,9 @@ func resourceFloatingIP() *schema.Resource {
 		Read:   resourceFloatingIPRead,
 		Update: resourceFloatingIPUpdate,
 		Delete: resourceFloatingIPDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceFloatingIPImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"region": &schema.Schema{
@@ -174,3 +179,36 @@ func resourceFloatingIPDelete(d *schema.ResourceData, meta interface{}) error {
 
 	return nil
 }
+
+// resourceFloatingIPImport accepts either the ID or the address of a
+// floating IP. Only nova-network floating IPs are supported.
+func resourceFloatingIPImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("network_service", "nova-network")
+
+	if net.ParseIP(d.Id()) != nil {
+		client, err := getClient("compute", d, meta)
+		if err != nil {
+			return nil, err
+		}
+
+		fips, err := listNovaNetworkFloatingIPs(client)
+		if err != nil {
+			return nil, err
+		}
+
+		found := false
+		for _, fip := range fips {
+			if fip.Ip == d.Id() {
+				d.SetId(strconv.Itoa(fip.Id))
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil, fmt.Errorf("Unable to find floating IP: %v", d.Id())
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,112 @@
+package openstack
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccComputeV2FloatingIP_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFloatingIP(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2FloatingIPDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2FloatingIP,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_floating_ip.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccComputeV2FloatingIP_importAddress(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckFloatingIP(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2FloatingIPDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2FloatingIP,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2FloatingIPImportAddress(
+						"openstack_floating_ip.accept_test"),
+				),
+			},
+		},
+	})
+}
+
+func testAccPreCheckFloatingIP(t *testing.T) {
+	testAccPreCheck(t)
+
+	if OS_POOL_NAME == "" {
+		t.Fatal("OS_POOL_NAME must be set for floating IP acceptance tests.")
+	}
+}
+
+// testAccCheckComputeV2FloatingIPImportAddress imports a floating IP by its
+// address and checks that it resolves to the same floating IP.
+func testAccCheckComputeV2FloatingIPImportAddress(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %v", n)
+		}
+
+		d := resourceFloatingIP().Data(nil)
+		d.SetId(rs.Primary.Attributes["ip"])
+
+		imported, err := resourceFloatingIPImport(d, testAccProvider.Meta())
+		if err != nil {
+			return err
+		}
+
+		if imported[0].Id() != rs.Primary.ID {
+			return fmt.Errorf("Imported %v instead of %v", imported[0].Id(), rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckComputeV2FloatingIPDestroy(s *terraform.State) error {
+	computeClient, err := testComputeClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_floating_ip" {
+			continue
+		}
+
+		fId, err := strconv.Atoi(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		_, err = getNovaNetworkFloatingIP(computeClient, fId)
+		if err == nil {
+			return fmt.Errorf("Floating IP still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccComputeV2FloatingIP = fmt.Sprintf(`
+	resource "openstack_floating_ip" "accept_test" {
+		region = "%s"
+		pool = "%s"
+		network_service = "nova-network"
+	}`,
+	OS_REGION_NAME, OS_POOL_NAME,
+)
@@ -24,6 +24,12 @@ func resourceInstance() *schema.Resource {
 		Read:   resourceInstanceRead,
 		Update: resourceInstanceUpdate,
 		Delete: resourceInstanceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceInstanceImport,
+		},
+
+		SchemaVersion: 1,
+		MigrateState:  resourceInstanceMigrateState,
 
 		Schema: map[string]*schema.Schema{
 			"region": &schema.Schema{
@@ -51,6 +57,8 @@ func resourceInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"image": imageLookupSchema(),
+
 			"flavor_id": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -63,10 +71,13 @@ func resourceInstance() *schema.Resource {
 				Computed: true,
 			},
 
+			"flavor": flavorLookupSchema(),
+
 			"security_groups": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
 				ForceNew: true, // TODO handle update
+				Computed: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 				Set: func(v interface{}) int {
 					return hashcode.String(v.(string))
@@ -106,7 +117,7 @@ func resourceInstance() *schema.Resource {
 			},
 
 			"network": &schema.Schema{
-				Type:     schema.TypeSet,
+				Type:     schema.TypeList,
 				Optional: true,
 				ForceNew: true, // TODO handle update
 				Computed: true,
@@ -114,22 +125,70 @@ func resourceInstance() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"uuid": &schema.Schema{
 							Type:     schema.TypeString,
-							Required: true,
+							Optional: true,
 							ForceNew: true,
+							Computed: true,
+						},
+						"name": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
 						},
 						"port": &schema.Schema{
 							Type:     schema.TypeString,
 							Optional: true,
 							ForceNew: true,
+							Computed: true,
 						},
+						// deprecated: use fixed_ip_v4 or fixed_ip_v6
 						"fixed_ip": &schema.Schema{
 							Type:     schema.TypeString,
 							Optional: true,
 							ForceNew: true,
 						},
+						"fixed_ip_v4": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
+						"fixed_ip_v6": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+							Computed: true,
+						},
+						// read-only
+						"mac": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"floating_ip": &schema.Schema{
+							Type:     schema.TypeString,
+							Computed: true,
+						},
 					},
 				},
-				Set: resourceInstanceNetworkHash,
+			},
+
+			// connection_network is the name of the network whose address
+			// is used for provisioner connections
+			"connection_network": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"access_ip_v4": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"access_ip_v6": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
 			},
 
 			"metadata": &schema.Schema{
@@ -182,7 +241,46 @@ func resourceInstance() *schema.Resource {
 				Set: resourceComputeVolumeAttachmentHash,
 			},
 
+			"wait_for": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"port": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"console_log": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"metadata_key": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"timeout": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Default:  "10m",
+						},
+					},
+				},
+			},
+
+			// console_output_lines is the number of console log lines
+			// stored in console_output and appended to build errors.
+			// 0 disables capturing.
+			"console_output_lines": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Default:  50,
+			},
+
 			// read-only
+			"console_output": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"created": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -218,12 +316,17 @@ func resourceInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 		userData = []byte(v.(string))
 	}
 
-	imageID, err := getImageID(client, d)
+	imageID, err := getImageID(client, d, meta)
+	if err != nil {
+		return err
+	}
+
+	flavorID, err := getFlavorID(client, d, meta)
 	if err != nil {
 		return err
 	}
 
-	flavorID, err := getFlavorID(client, d)
+	networks, err := buildInstanceNetworks(d, meta)
 	if err != nil {
 		return err
 	}
@@ -234,13 +337,22 @@ func resourceInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 		ImageRef:       imageID,
 		FlavorRef:      flavorID,
 		SecurityGroups: buildInstanceSecurityGroups(d),
-		Networks:       buildInstanceNetworks(d),
+		Networks:       networks,
 		UserData:       userData,
 		AdminPass:      d.Get("admin_pass").(string),
 		ConfigDrive:    d.Get("config_drive").(bool),
 		Metadata:       buildInstanceMetadata(d),
 	}
 
+	// sent on create so new instances don't show a diff on the next plan
+	if d.Get("access_ip_v4").(string) != "" || d.Get("access_ip_v6").(string) != "" {
+		createOpts = &accessIPCreateOptsExt{
+			CreateOptsBuilder: createOpts,
+			AccessIPv4:        d.Get("access_ip_v4").(string),
+			AccessIPv6:        d.Get("access_ip_v6").(string),
+		}
+	}
+
 	if keyName, ok := d.Get("key_name").(string); ok && keyName != "" {
 		createOpts = &keypairs.CreateOptsExt{
 			createOpts,
@@ -255,7 +367,7 @@ func resourceInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"BUILD"},
-		Target:     "ACTIVE",
+		Target:     []string{"ACTIVE"},
 		Refresh:    waitForServerState(client, newServer),
 		Timeout:    30 * time.Minute,
 		Delay:      10 * time.Second,
@@ -265,12 +377,12 @@ func resourceInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 	_, err = stateConf.WaitForState()
 
 	if err != nil {
-		return err
+		return appendConsoleOutput(client, newServer.ID, d, err)
 	}
 
 	// get full info about the new server
 	d.SetId(newServer.ID)
-	if err := setServerDetails(client, newServer.ID, d); err != nil {
+	if err := setServerDetails(client, newServer.ID, d, meta); err != nil {
 		return err
 	}
 
@@ -289,10 +401,29 @@ func resourceInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	// refresh info about the server
-	if err := setServerDetails(client, newServer.ID, d); err != nil {
+	if err := setServerDetails(client, newServer.ID, d, meta); err != nil {
 		return err
 	}
 
+	// Initialize the connection info
+	host, err := getInstanceAccessAddress(d)
+	if err != nil {
+		return err
+	}
+	if host != "" {
+		d.SetConnInfo(map[string]string{
+			"type": "ssh",
+			"host": host,
+		})
+	}
+
+	// wait until the instance is actually usable
+	if v := d.Get("wait_for"); v != nil {
+		if err := waitForInstanceReady(client, d.Id(), v.([]interface{}), d); err != nil {
+			return appendConsoleOutput(client, d.Id(), d, err)
+		}
+	}
+
 	// FIXME: add floating IP support
 	/*
 	   pool := d.Get("floating_ip_pool").(string)
@@ -340,16 +471,12 @@ func resourceInstanceCreate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceInstanceRead(d *schema.ResourceData, meta interface{}) error {
-	if err := checkParameters(d); err != nil {
-		return err
-	}
-
 	client, err := getClient("compute", d, meta)
 	if err != nil {
 		return err
 	}
 
-	if err := setServerDetails(client, d.Id(), d); err != nil {
+	if err := setServerDetails(client, d.Id(), d, meta); err != nil {
 		return err
 	}
 
@@ -382,6 +509,20 @@ func resourceInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 		d.SetPartial("name")
 	}
 
+	if d.HasChange("access_ip_v4") || d.HasChange("access_ip_v6") {
+		_, err := servers.Update(client, server.ID, servers.UpdateOpts{
+			AccessIPv4: d.Get("access_ip_v4").(string),
+			AccessIPv6: d.Get("access_ip_v6").(string),
+		}).Extract()
+
+		if err != nil {
+			return err
+		}
+
+		d.SetPartial("access_ip_v4")
+		d.SetPartial("access_ip_v6")
+	}
+
 	if d.HasChange("flavor_ref") {
 		opts := &servers.ResizeOpts{
 			FlavorRef: d.Get("flavor_ref").(string),
@@ -393,7 +534,7 @@ func resourceInstanceUpdate(d *schema.ResourceData, meta interface{}) error {
 
 		stateConf := &resource.StateChangeConf{
 			Pending:    []string{"ACTIVE", "RESIZE"},
-			Target:     "VERIFY_RESIZE",
+			Target:     []string{"VERIFY_RESIZE"},
 			Refresh:    waitForServerState(client, server),
 			Timeout:    30 * time.Minute,
 			Delay:      10 * time.Second,
@@ -467,7 +608,7 @@ func resourceInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"ACTIVE", "ERROR"},
-		Target:     "DELETED",
+		Target:     []string{"DELETED"},
 		Refresh:    waitForServerState(client, server),
 		Timeout:    30 * time.Minute,
 		Delay:      10 * time.Second,
@@ -479,6 +620,14 @@ func resourceInstanceDelete(d *schema.ResourceData, meta interface{}) error {
 	return err
 }
 
+// resourceInstanceImport fills in the defaults of the arguments
+// that can't be read back from the instance.
+func resourceInstanceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("console_output_lines", 50)
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func waitForServerState(client *gophercloud.ServiceClient, server *servers.Server) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		latest, err := servers.Get(client, server.ID).Extract()
@@ -498,33 +647,35 @@ func waitForServerState(client *gophercloud.ServiceClient, server *servers.Serve
 func checkParameters(d *schema.ResourceData) error {
 	imageID := d.Get("image_id").(string)
 	imageName := d.Get("image_name").(string)
-	if imageID == "" && imageName == "" {
-		return errors.New("At least one of image_id or image_name is required.")
+	_, hasImage := d.GetOk("image")
+	if imageID == "" && imageName == "" && !hasImage {
+		return errors.New("At least one of image_id, image_name or image is required.")
 	}
 
 	flavorID := d.Get("flavor_id").(string)
 	flavorName := d.Get("flavor_name").(string)
-	if flavorID == "" && flavorName == "" {
-		return errors.New("At least one of flavor_id or flavor_name is required.")
+	_, hasFlavor := d.GetOk("flavor")
+	if flavorID == "" && flavorName == "" && !hasFlavor {
+		return errors.New("At least one of flavor_id, flavor_name or flavor is required.")
 	}
 
 	return nil
 }
 
-func setServerDetails(client *gophercloud.ServiceClient, serverID string, d *schema.ResourceData) error {
+func setServerDetails(client *gophercloud.ServiceClient, serverID string, d *schema.ResourceData, meta interface{}) error {
 	server, err := servers.Get(client, serverID).Extract()
 	if err != nil {
 		return err
 	}
 	log.Printf("[INFO] Server info: %v", server)
 
-	flavor, err := getFlavor(client, server.Flavor["id"].(string))
+	flavor, err := getFlavor(client, d, meta, server.Flavor["id"].(string))
 	if err != nil {
 		return err
 	}
 	log.Printf("[INFO] Flavor info: %v", flavor)
 
-	image, err := getImage(client, server.Image["id"].(string))
+	image, err := getImage(client, d, meta, server.Image["id"].(string))
 	if err != nil {
 		return err
 	}
@@ -537,6 +688,15 @@ func setServerDetails(client *gophercloud.ServiceClient, serverID string, d *sch
 	d.Set("updated", server.Updated)
 	d.Set("created", server.Created)
 	d.Set("key_name", server.KeyName)
+
+	// security groups
+	var secGroups []string
+	for _, sg := range server.SecurityGroups {
+		if name, ok := sg["name"].(string); ok {
+			secGroups = append(secGroups, name)
+		}
+	}
+	d.Set("security_groups", secGroups)
 	d.Set("image_id", image.ID)
 	d.Set("image_name", image.Name)
 	d.Set("flavor_id", flavor.ID)
@@ -562,6 +722,22 @@ func setServerDetails(client *gophercloud.ServiceClient, serverID string, d *sch
 	log.Printf("[INFO] addrs: %v", addrs)
 	d.Set("network_info", addrs)
 
+	// structured network details
+	networks := getInstanceNetworks(client, server.ID, server.Addresses, d)
+	log.Printf("[INFO] networks: %v", networks)
+	d.Set("network", networks)
+	d.Set("access_ip_v4", server.AccessIPv4)
+	d.Set("access_ip_v6", server.AccessIPv6)
+
+	// console output
+	if lines := d.Get("console_output_lines").(int); lines > 0 {
+		output, err := getInstanceConsoleOutput(client, server.ID, lines)
+		if err != nil {
+			log.Printf("[INFO] Unable to get console output: %v", err)
+		}
+		d.Set("console_output", output)
+	}
+
 	// volume attachments
 	vas, err := getVolumeAttachments(client, d.Id())
 	if err != nil {
@@ -582,38 +758,103 @@ func setServerDetails(client *gophercloud.ServiceClient, serverID string, d *sch
 	return nil
 }
 
-func resourceInstanceNetworkHash(v interface{}) int {
-	var buf bytes.Buffer
-	m := v.(map[string]interface{})
-	buf.WriteString(fmt.Sprintf("%s-", m["uuid"].(string)))
-	buf.WriteString(fmt.Sprintf("%s-", m["port"].(string)))
-	buf.WriteString(fmt.Sprintf("%s-", m["fixed_ip"].(string)))
-	return hashcode.String(buf.String())
+// getInstanceAccessAddress determines the address provisioners should
+// connect to: a floating IP, then the access IPs, then the first fixed
+// address. If connection_network is set, only that network is used, and
+// it is an error if the instance isn't attached to it.
+func getInstanceAccessAddress(d *schema.ResourceData) (string, error) {
+	connNetwork := d.Get("connection_network").(string)
+
+	var nets []map[string]interface{}
+	for _, v := range d.Get("network").([]interface{}) {
+		n := v.(map[string]interface{})
+		if connNetwork == "" || n["name"] == connNetwork || n["uuid"] == connNetwork {
+			nets = append(nets, n)
+		}
+	}
+
+	if connNetwork != "" && len(nets) == 0 {
+		return "", fmt.Errorf("connection_network %s is not one of the networks of instance %s.", connNetwork, d.Id())
+	}
+
+	for _, n := range nets {
+		if ip := n["floating_ip"].(string); ip != "" {
+			return ip, nil
+		}
+	}
+
+	if connNetwork == "" {
+		if ip := d.Get("access_ip_v4").(string); ip != "" {
+			return ip, nil
+		}
+		if ip := d.Get("access_ip_v6").(string); ip != "" {
+			return ip, nil
+		}
+	}
+
+	for _, n := range nets {
+		if ip := n["fixed_ip_v4"].(string); ip != "" {
+			return ip, nil
+		}
+		if ip := n["fixed_ip_v6"].(string); ip != "" {
+			return ip, nil
+		}
+	}
+
+	return "", nil
 }
 
-func buildInstanceNetworks(d *schema.ResourceData) []servers.Network {
+// buildInstanceNetworks resolves the configured networks, looking up
+// networks that are given by name.
+func buildInstanceNetworks(d *schema.ResourceData, meta interface{}) ([]servers.Network, error) {
 	var networks []servers.Network
 	if v, ok := d.GetOk("network"); ok {
 		log.Printf("[INFO] network: %v", v)
-		vs := v.(*schema.Set).List()
-		if len(vs) > 0 {
-			for _, v := range vs {
-				net := v.(map[string]interface{})
-				networks = append(networks, servers.Network{
-					UUID:    net["uuid"].(string),
-					Port:    net["port"].(string),
-					FixedIP: net["fixed_ip"].(string),
-				})
+		for _, v := range v.([]interface{}) {
+			net := v.(map[string]interface{})
+			fixedIP := net["fixed_ip"].(string)
+			if v4 := net["fixed_ip_v4"].(string); v4 != "" {
+				fixedIP = v4
+			} else if v6 := net["fixed_ip_v6"].(string); v6 != "" {
+				fixedIP = v6
+			}
+
+			uuid := net["uuid"].(string)
+			name := net["name"].(string)
+			port := net["port"].(string)
+			if uuid == "" && name != "" {
+				var err error
+				uuid, err = getNetworkID(d, meta, name)
+				if err != nil {
+					return nil, err
+				}
+			}
+			if uuid == "" && port == "" {
+				return nil, fmt.Errorf("One of uuid, name or port is required in a network block.")
 			}
+
+			networks = append(networks, servers.Network{
+				UUID:    uuid,
+				Port:    port,
+				FixedIP: fixedIP,
+			})
 		}
 	} else {
 		nets := d.Get("networks").(*schema.Set)
 		for _, v := range nets.List() {
 			log.Printf("[INFO] network: %v", v)
-			networks = append(networks, servers.Network{UUID: v.(string)})
+			uuid := v.(string)
+			if !isUUID(uuid) {
+				var err error
+				uuid, err = getNetworkID(d, meta, uuid)
+				if err != nil {
+					return nil, err
+				}
+			}
+			networks = append(networks, servers.Network{UUID: uuid})
 		}
 	}
-	return networks
+	return networks, nil
 }
 
 func buildInstanceSecurityGroups(d *schema.ResourceData) []string {
@@ -639,6 +880,31 @@ func buildInstanceMetadata(d *schema.ResourceData) map[string]string {
 	return metadata
 }
 
+// accessIPCreateOptsExt adds the access IPs to a server create request,
+// the same way keypairs.CreateOptsExt adds the key name.
+type accessIPCreateOptsExt struct {
+	servers.CreateOptsBuilder
+	AccessIPv4 string
+	AccessIPv6 string
+}
+
+func (opts accessIPCreateOptsExt) ToServerCreateMap() (map[string]interface{}, error) {
+	base, err := opts.CreateOptsBuilder.ToServerCreateMap()
+	if err != nil {
+		return nil, err
+	}
+
+	server := base["server"].(map[string]interface{})
+	if opts.AccessIPv4 != "" {
+		server["accessIPv4"] = opts.AccessIPv4
+	}
+	if opts.AccessIPv6 != "" {
+		server["accessIPv6"] = opts.AccessIPv6
+	}
+
+	return base, nil
+}
+
 func resourceComputeVolumeAttachmentHash(v interface{}) int {
 	var buf bytes.Buffer
 	m := v.(map[string]interface{})
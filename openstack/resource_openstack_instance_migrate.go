@@ -0,0 +1,203 @@
+package openstack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+)
+
+func resourceInstanceMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found OpenStack Instance State v0; migrating to v1")
+		return migrateInstanceStateV0toV1(is, getInstanceAddressOrder(is, meta))
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+// migrateInstanceStateV0toV1 turns network from a set into a list. The set
+// entries are keyed by their hash, the list entries by their position.
+// The set didn't keep the configured order, so the entries are put in the
+// order of their MAC or IP addresses in addressOrder, which is the order
+// the networks were requested in. Entries that aren't in addressOrder go
+// last.
+func migrateInstanceStateV0toV1(is *terraform.InstanceState, addressOrder []string) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	log.Printf("[DEBUG] Attributes before migration: %#v", is.Attributes)
+
+	var hashes []string
+	for k := range is.Attributes {
+		parts := strings.SplitN(k, ".", 3)
+		if len(parts) != 3 || parts[0] != "network" {
+			continue
+		}
+		if !containsString(hashes, parts[1]) {
+			hashes = append(hashes, parts[1])
+		}
+	}
+	sort.Strings(hashes)
+
+	positions := make([]int, len(hashes))
+	for i, hash := range hashes {
+		positions[i] = len(addressOrder)
+		for _, attr := range []string{"mac", "fixed_ip_v4", "fixed_ip_v6", "fixed_ip"} {
+			if p := indexOfString(addressOrder, is.Attributes["network."+hash+"."+attr]); p != -1 {
+				positions[i] = p
+				break
+			}
+		}
+	}
+	sort.Stable(networksByPosition{hashes, positions})
+
+	migrated := make(map[string]string)
+	for k, v := range is.Attributes {
+		parts := strings.SplitN(k, ".", 3)
+		if len(parts) != 3 || parts[0] != "network" {
+			continue
+		}
+
+		for i, hash := range hashes {
+			if parts[1] == hash {
+				delete(is.Attributes, k)
+				migrated[fmt.Sprintf("network.%d.%s", i, parts[2])] = v
+				break
+			}
+		}
+	}
+	for k, v := range migrated {
+		is.Attributes[k] = v
+	}
+
+	log.Printf("[DEBUG] Attributes after migration: %#v", is.Attributes)
+	return is, nil
+}
+
+type networksByPosition struct {
+	hashes    []string
+	positions []int
+}
+
+func (a networksByPosition) Len() int { return len(a.hashes) }
+func (a networksByPosition) Swap(i, j int) {
+	a.hashes[i], a.hashes[j] = a.hashes[j], a.hashes[i]
+	a.positions[i], a.positions[j] = a.positions[j], a.positions[i]
+}
+func (a networksByPosition) Less(i, j int) bool { return a.positions[i] < a.positions[j] }
+
+// getInstanceAddressOrder returns the MAC and IP addresses of an instance
+// in the order nova lists them, or nil if the instance can't be read.
+func getInstanceAddressOrder(is *terraform.InstanceState, meta interface{}) []string {
+	if is.Empty() || meta == nil {
+		return nil
+	}
+
+	client, err := meta.(*Config).computeClient(is.Attributes["region"])
+	if err != nil {
+		log.Printf("[INFO] Unable to get a compute client, networks are migrated in hash order: %v", err)
+		return nil
+	}
+
+	order, err := listInstanceAddressOrder(client, is.ID)
+	if err != nil {
+		log.Printf("[INFO] Unable to retrieve instance %s, networks are migrated in hash order: %v", is.ID, err)
+		return nil
+	}
+
+	return order
+}
+
+// listInstanceAddressOrder returns the MAC and IP addresses of an
+// instance in the order of its addresses. Nova lists them in the order the
+// networks were requested, which gophercloud loses by decoding them into
+// a map.
+func listInstanceAddressOrder(client *gophercloud.ServiceClient, instanceId string) ([]string, error) {
+	type serverAddresses struct {
+		Addresses json.RawMessage `json:"addresses"`
+	}
+	var server serverAddresses
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("servers", instanceId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Server *serverAddresses `json:"server"`
+			}{&server},
+			OkCodes: []int{200},
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseAddressOrder(server.Addresses)
+}
+
+// parseAddressOrder reads the addresses object of a server, keeping the
+// order of its keys.
+func parseAddressOrder(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if t, err := dec.Token(); err != nil || t != json.Delim('{') {
+		return nil, fmt.Errorf("Unexpected addresses: %s", raw)
+	}
+
+	var order []string
+	for dec.More() {
+		// the network label
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+
+		var addrs []struct {
+			Addr string `json:"addr"`
+			MAC  string `json:"OS-EXT-IPS-MAC:mac_addr"`
+		}
+		if err := dec.Decode(&addrs); err != nil {
+			return nil, err
+		}
+
+		for _, a := range addrs {
+			if a.MAC != "" {
+				order = append(order, a.MAC)
+			}
+			order = append(order, a.Addr)
+		}
+	}
+
+	return order, nil
+}
+
+func containsString(list []string, s string) bool {
+	return indexOfString(list, s) != -1
+}
+
+func indexOfString(list []string, s string) int {
+	if s == "" {
+		return -1
+	}
+
+	for i, v := range list {
+		if v == s {
+			return i
+		}
+	}
+
+	return -1
+}
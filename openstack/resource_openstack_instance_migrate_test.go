@@ -0,0 +1,151 @@
+package openstack
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestInstanceMigrateState(t *testing.T) {
+	cases := map[string]struct {
+		StateVersion int
+		Attributes   map[string]string
+		Expected     map[string]string
+	}{
+		"v0_0 two networks": {
+			StateVersion: 0,
+			Attributes: map[string]string{
+				"name":                        "web",
+				"network.#":                   "2",
+				"network.3121871617.uuid":     "net-b",
+				"network.3121871617.port":     "",
+				"network.3121871617.fixed_ip": "",
+				"network.1057164946.uuid":     "net-a",
+				"network.1057164946.port":     "port-a",
+				"network.1057164946.fixed_ip": "10.0.0.5",
+			},
+			Expected: map[string]string{
+				"name":               "web",
+				"network.#":          "2",
+				"network.0.uuid":     "net-a",
+				"network.0.port":     "port-a",
+				"network.0.fixed_ip": "10.0.0.5",
+				"network.1.uuid":     "net-b",
+				"network.1.port":     "",
+				"network.1.fixed_ip": "",
+			},
+		},
+		"v0_0 no networks": {
+			StateVersion: 0,
+			Attributes: map[string]string{
+				"name":      "web",
+				"network.#": "0",
+			},
+			Expected: map[string]string{
+				"name":      "web",
+				"network.#": "0",
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		is := &terraform.InstanceState{
+			ID:         "i-abc123",
+			Attributes: tc.Attributes,
+		}
+		is, err := resourceInstanceMigrateState(tc.StateVersion, is, nil)
+		if err != nil {
+			t.Fatalf("bad: %s, err: %#v", tn, err)
+		}
+
+		if len(is.Attributes) != len(tc.Expected) {
+			t.Fatalf("bad: %s\n\n expected: %#v\n got: %#v", tn, tc.Expected, is.Attributes)
+		}
+		for k, v := range tc.Expected {
+			if is.Attributes[k] != v {
+				t.Fatalf("bad: %s\n\n expected: %#v -> %#v\n got: %#v -> %#v\n in: %#v",
+					tn, k, v, k, is.Attributes[k], is.Attributes)
+			}
+		}
+	}
+}
+
+// the hashes sort in the opposite order to the one the networks were
+// configured and requested in
+func TestInstanceMigrateState_addressOrder(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "i-abc123",
+		Attributes: map[string]string{
+			"network.#":                      "2",
+			"network.1057164946.uuid":        "net-b",
+			"network.1057164946.mac":         "fa:16:3e:00:00:02",
+			"network.1057164946.fixed_ip_v4": "10.0.1.5",
+			"network.3121871617.uuid":        "net-a",
+			"network.3121871617.mac":         "",
+			"network.3121871617.fixed_ip_v4": "10.0.0.5",
+		},
+	}
+	order := []string{"10.0.0.5", "fa:16:3e:00:00:02", "10.0.1.5"}
+
+	is, err := migrateInstanceStateV0toV1(is, order)
+	if err != nil {
+		t.Fatalf("err: %#v", err)
+	}
+
+	expected := map[string]string{
+		"network.#":             "2",
+		"network.0.uuid":        "net-a",
+		"network.0.mac":         "",
+		"network.0.fixed_ip_v4": "10.0.0.5",
+		"network.1.uuid":        "net-b",
+		"network.1.mac":         "fa:16:3e:00:00:02",
+		"network.1.fixed_ip_v4": "10.0.1.5",
+	}
+	if len(is.Attributes) != len(expected) {
+		t.Fatalf("expected: %#v\n got: %#v", expected, is.Attributes)
+	}
+	for k, v := range expected {
+		if is.Attributes[k] != v {
+			t.Fatalf("expected: %#v -> %#v\n got: %#v -> %#v\n in: %#v",
+				k, v, k, is.Attributes[k], is.Attributes)
+		}
+	}
+}
+
+func TestParseAddressOrder(t *testing.T) {
+	raw := []byte(`{
+		"web": [{"addr": "10.0.1.5", "version": 4, "OS-EXT-IPS-MAC:mac_addr": "fa:16:3e:00:00:02"}],
+		"db": [{"addr": "10.0.0.5", "version": 4}, {"addr": "fd00::5", "version": 6}]
+	}`)
+
+	order, err := parseAddressOrder(raw)
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+
+	expected := []string{"fa:16:3e:00:00:02", "10.0.1.5", "10.0.0.5", "fd00::5"}
+	if !reflect.DeepEqual(order, expected) {
+		t.Fatalf("expected: %v, got: %v", expected, order)
+	}
+}
+
+func TestInstanceMigrateState_empty(t *testing.T) {
+	var is *terraform.InstanceState
+
+	// should handle nil
+	is, err := resourceInstanceMigrateState(0, is, nil)
+	if err != nil {
+		t.Fatalf("err: %#v", err)
+	}
+	if is != nil {
+		t.Fatalf("expected nil instancestate, got: %#v", is)
+	}
+
+	// should handle non-nil but empty
+	is = &terraform.InstanceState{}
+	is, err = resourceInstanceMigrateState(0, is, nil)
+	if err != nil {
+		t.Fatalf("err: %#v", err)
+	}
+}
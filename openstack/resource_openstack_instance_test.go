@@ -0,0 +1,101 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/rackspace/gophercloud/openstack/compute/v2/servers"
+)
+
+func TestAccComputeV2Instance_importBasic(t *testing.T) {
+	var server servers.Server
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2InstanceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Instance,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2InstanceExists(
+						t, "openstack_instance.accept_test", &server),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_instance.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// arguments that only live in the config
+				ImportStateVerifyIgnore: []string{
+					"admin_pass", "connection_network", "console_output",
+					"networks", "user_data", "wait_for",
+				},
+			},
+		},
+	})
+}
+
+func testAccCheckComputeV2InstanceExists(t *testing.T, n string, server *servers.Server) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %v", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		computeClient, err := testComputeClient()
+		if err != nil {
+			return err
+		}
+
+		found, err := servers.Get(computeClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Instance not found: %v", found.ID)
+		}
+
+		*server = *found
+
+		return nil
+	}
+}
+
+func testAccCheckComputeV2InstanceDestroy(s *terraform.State) error {
+	computeClient, err := testComputeClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_instance" {
+			continue
+		}
+
+		_, err := servers.Get(computeClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Instance still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccComputeV2Instance = fmt.Sprintf(`
+	resource "openstack_instance" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		image_id = "%s"
+		flavor_id = "%s"
+	}`,
+	OS_REGION_NAME, OS_IMAGE_ID, OS_FLAVOR_ID,
+)
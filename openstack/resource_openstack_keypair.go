@@ -1,7 +1,9 @@
 package openstack
 
 import (
+	"io/ioutil"
 	"log"
+	"os"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/rackspace/gophercloud"
@@ -14,6 +16,9 @@ func resourceKeypair() *schema.Resource {
 		Read:   resourceKeypairRead,
 		Update: nil,
 		Delete: resourceKeypairDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"region": &schema.Schema{
@@ -25,17 +30,32 @@ func resourceKeypair() *schema.Resource {
 			"name": &schema.Schema{
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 
+			// if public_key is omitted, nova generates the keypair
 			"public_key": &schema.Schema{
 				Type:     schema.TypeString,
-				Required: true,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			// private_key_file is where a generated private key is saved
+			"private_key_file": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
 			},
 
 			// read-only / exported
+			"private_key": &schema.Schema{
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
 			"fingerprint": &schema.Schema{
 				Type:     schema.TypeString,
-				Optional: true,
 				Computed: true,
 			},
 			"user_id": &schema.Schema{
@@ -67,6 +87,18 @@ func resourceKeypairCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	d.SetId(newKey.Name)
+
+	// the private key is only returned when nova generated the keypair
+	if newKey.PrivateKey != "" {
+		d.Set("private_key", newKey.PrivateKey)
+
+		if keyFile := d.Get("private_key_file").(string); keyFile != "" {
+			if err := writePrivateKeyFile(keyFile, newKey.PrivateKey); err != nil {
+				return err
+			}
+		}
+	}
+
 	if err := setKeypairDetails(client, newKey.Name, d); err != nil {
 		return err
 	}
@@ -115,3 +147,12 @@ func setKeypairDetails(client *gophercloud.ServiceClient, keyName string, d *sch
 
 	return nil
 }
+
+func writePrivateKeyFile(path, privateKey string) error {
+	if err := ioutil.WriteFile(path, []byte(privateKey), 0600); err != nil {
+		return err
+	}
+
+	// WriteFile leaves the permissions of an existing file alone
+	return os.Chmod(path, 0600)
+}
@@ -36,6 +36,45 @@ func TestAccComputeV2Keypair(t *testing.T) {
 	})
 }
 
+func TestAccComputeV2Keypair_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2KeypairDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Keypair,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_keypair.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccComputeV2Keypair_generated(t *testing.T) {
+	var keypair keypairs.KeyPair
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2KeypairDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Keypair_generated,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckComputeV2KeypairExists(
+						t, "openstack_keypair.accept_test", &keypair),
+					testAccCheckComputeV2KeypairGenerated(
+						"openstack_keypair.accept_test", &keypair),
+				),
+			},
+		},
+	})
+}
+
 func testComputeClient() (*gophercloud.ServiceClient, error) {
 	config := testAccProvider.Meta().(*Config)
 	computeClient, err := config.computeClient(OS_REGION_NAME)
@@ -76,6 +115,29 @@ func testAccCheckComputeV2KeypairExists(t *testing.T, n string, keypair *keypair
 	}
 }
 
+func testAccCheckComputeV2KeypairGenerated(n string, keypair *keypairs.KeyPair) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %v", n)
+		}
+
+		if rs.Primary.Attributes["private_key"] == "" {
+			return fmt.Errorf("No private key was set")
+		}
+
+		if rs.Primary.Attributes["public_key"] != keypair.PublicKey {
+			return fmt.Errorf("Public key does not match: %v", rs.Primary.Attributes["public_key"])
+		}
+
+		if rs.Primary.Attributes["fingerprint"] != keypair.Fingerprint {
+			return fmt.Errorf("Fingerprint does not match: %v", rs.Primary.Attributes["fingerprint"])
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckComputeV2KeypairDestroy(s *terraform.State) error {
 	computeClient, err := testComputeClient()
 	if err != nil {
@@ -104,3 +166,11 @@ var testAccComputeV2Keypair = fmt.Sprintf(`
 	}`,
 	OS_REGION_NAME, public_key,
 )
+
+var testAccComputeV2Keypair_generated = fmt.Sprintf(`
+	resource "openstack_keypair" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}`,
+	OS_REGION_NAME,
+)
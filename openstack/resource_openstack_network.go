@@ -0,0 +1,311 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/networks"
+)
+
+func resourceNetwork() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceNetworkCreate,
+		Read:   resourceNetworkRead,
+		Update: resourceNetworkUpdate,
+		Delete: resourceNetworkDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"admin_state_up": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// shared, external and segments are admin-only by default
+			"shared": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"external": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"mtu": &schema.Schema{
+				Type:     schema.TypeInt,
+				Optional: true,
+				Computed: true,
+			},
+
+			"segments": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_type": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+						"physical_network": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"segmentation_id": &schema.Schema{
+							Type:     schema.TypeInt,
+							Optional: true,
+							ForceNew: true,
+						},
+					},
+				},
+			},
+
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			// read-only / exported
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"subnets": &schema.Schema{
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourceNetworkCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	opts := map[string]interface{}{
+		"name":           d.Get("name").(string),
+		"admin_state_up": d.Get("admin_state_up").(bool),
+	}
+
+	// only send admin-only attributes when they're used,
+	// so that regular users can create networks
+	if d.Get("shared").(bool) {
+		opts["shared"] = true
+	}
+	if d.Get("external").(bool) {
+		opts["router:external"] = true
+	}
+	if v := d.Get("mtu").(int); v > 0 {
+		opts["mtu"] = v
+	}
+	if v := d.Get("tenant_id").(string); v != "" {
+		opts["tenant_id"] = v
+	}
+
+	segments := getNetworkSegmentsFromConfig(d)
+	switch len(segments) {
+	case 0:
+	case 1:
+		opts["provider:network_type"] = segments[0].NetworkType
+		if segments[0].PhysicalNetwork != "" {
+			opts["provider:physical_network"] = segments[0].PhysicalNetwork
+		}
+		if segments[0].SegmentationId != 0 {
+			opts["provider:segmentation_id"] = segments[0].SegmentationId
+		}
+	default:
+		opts["segments"] = segments
+	}
+
+	log.Printf("[INFO] Network create options: %v", opts)
+
+	network, err := createNeutronNetwork(client, opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(network.Id)
+	invalidateLookups(d, meta, "network")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"BUILD"},
+		Target:     []string{"ACTIVE", "DOWN"},
+		Refresh:    waitForNetworkState(client, network.Id),
+		Timeout:    10 * time.Minute,
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return err
+	}
+
+	return resourceNetworkRead(d, meta)
+}
+
+func resourceNetworkRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	network, err := getNeutronNetwork(client, d.Id())
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] Network info: %v", network)
+
+	d.Set("name", network.Name)
+	d.Set("admin_state_up", network.AdminStateUp)
+	d.Set("shared", network.Shared)
+	d.Set("external", network.External)
+	d.Set("mtu", network.MTU)
+	d.Set("tenant_id", network.TenantId)
+	d.Set("status", network.Status)
+	d.Set("subnets", network.Subnets)
+
+	var segments []map[string]interface{}
+	for _, s := range getNetworkSegments(network) {
+		segments = append(segments, map[string]interface{}{
+			"network_type":     s.NetworkType,
+			"physical_network": s.PhysicalNetwork,
+			"segmentation_id":  s.SegmentationId,
+		})
+	}
+	d.Set("segments", segments)
+
+	return nil
+}
+
+func resourceNetworkUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	opts := make(map[string]interface{})
+	if d.HasChange("name") {
+		opts["name"] = d.Get("name").(string)
+	}
+	if d.HasChange("admin_state_up") {
+		opts["admin_state_up"] = d.Get("admin_state_up").(bool)
+	}
+	if d.HasChange("shared") {
+		opts["shared"] = d.Get("shared").(bool)
+	}
+	if d.HasChange("external") {
+		opts["router:external"] = d.Get("external").(bool)
+	}
+	if d.HasChange("mtu") {
+		opts["mtu"] = d.Get("mtu").(int)
+	}
+
+	if len(opts) > 0 {
+		log.Printf("[INFO] Network update options: %v", opts)
+
+		if err := updateNeutronNetwork(client, d.Id(), opts); err != nil {
+			return err
+		}
+
+		invalidateLookups(d, meta, "network")
+	}
+
+	return resourceNetworkRead(d, meta)
+}
+
+func resourceNetworkDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	// neutron removes dhcp ports itself, but the ports of instances
+	// being destroyed at the same time can take a while to go away
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		err := networks.Delete(client, d.Id()).ExtractErr()
+		if err == nil {
+			return nil
+		}
+
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok {
+			switch httpStatus.Actual {
+			case 404:
+				return nil
+			case 409:
+				log.Printf("[INFO] Network %s is in use, retrying: %v", d.Id(), err)
+				return resource.RetryableError(err)
+			}
+		}
+
+		return resource.NonRetryableError(err)
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error deleting network %s: %v", d.Id(), err)
+	}
+
+	invalidateLookups(d, meta, "network")
+
+	return nil
+}
+
+func getNetworkSegmentsFromConfig(d *schema.ResourceData) []NeutronNetworkSegment {
+	var segments []NeutronNetworkSegment
+	for _, v := range d.Get("segments").([]interface{}) {
+		s := v.(map[string]interface{})
+		segments = append(segments, NeutronNetworkSegment{
+			NetworkType:     s["network_type"].(string),
+			PhysicalNetwork: s["physical_network"].(string),
+			SegmentationId:  s["segmentation_id"].(int),
+		})
+	}
+
+	return segments
+}
+
+func waitForNetworkState(client *gophercloud.ServiceClient, networkId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		network, err := getNeutronNetwork(client, networkId)
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] Network %s status: %s", networkId, network.Status)
+		return network, network.Status, nil
+	}
+}
@@ -0,0 +1,88 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNetworkingV2Network(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2NetworkDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2Network,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_network.accept_test", "name", "accept_test"),
+					resource.TestCheckResourceAttr("openstack_network.accept_test", "admin_state_up", "true"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNetworkingV2Network_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_network.accept_test", "name", "accept_test_updated"),
+					resource.TestCheckResourceAttr("openstack_network.accept_test", "admin_state_up", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetworkingV2Network_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2NetworkDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2Network,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_network.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2NetworkDestroy(s *terraform.State) error {
+	networkClient, err := testNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_network" {
+			continue
+		}
+
+		_, err := getNeutronNetwork(networkClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Network still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccNetworkingV2Network = fmt.Sprintf(`
+	resource "openstack_network" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}`,
+	OS_REGION_NAME,
+)
+
+var testAccNetworkingV2Network_update = fmt.Sprintf(`
+	resource "openstack_network" "accept_test" {
+		region = "%s"
+		name = "accept_test_updated"
+		admin_state_up = false
+	}`,
+	OS_REGION_NAME,
+)
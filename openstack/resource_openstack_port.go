@@ -0,0 +1,361 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/ports"
+)
+
+func resourcePort() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePortCreate,
+		Read:   resourcePortRead,
+		Update: resourcePortUpdate,
+		Delete: resourcePortDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"network_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"admin_state_up": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"mac_address": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"fixed_ips": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subnet_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"ip_address": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateIPAddress,
+						},
+					},
+				},
+			},
+
+			// neutron adds the default security group when none are given
+			"security_groups": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set: func(v interface{}) int {
+					return hashcode.String(v.(string))
+				},
+			},
+
+			"allowed_address_pairs": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip_address": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"mac_address": &schema.Schema{
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+
+			"port_security_enabled": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"vnic_type": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validatePortVNICType,
+			},
+
+			"device_owner": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"device_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			// read-only / exported
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePortCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	opts := map[string]interface{}{
+		"network_id":     d.Get("network_id").(string),
+		"name":           d.Get("name").(string),
+		"admin_state_up": d.Get("admin_state_up").(bool),
+	}
+
+	if v := d.Get("mac_address").(string); v != "" {
+		opts["mac_address"] = v
+	}
+	if fixedIPs := getPortFixedIPs(d); len(fixedIPs) > 0 {
+		opts["fixed_ips"] = fixedIPs
+	}
+	if pairs := getPortAllowedAddressPairs(d); len(pairs) > 0 {
+		opts["allowed_address_pairs"] = pairs
+	}
+	if v := d.Get("vnic_type").(string); v != "" {
+		opts["binding:vnic_type"] = v
+	}
+	if v := d.Get("device_owner").(string); v != "" {
+		opts["device_owner"] = v
+	}
+	if v := d.Get("device_id").(string); v != "" {
+		opts["device_id"] = v
+	}
+	if v := d.Get("tenant_id").(string); v != "" {
+		opts["tenant_id"] = v
+	}
+
+	secGroups := getPortSecurityGroups(d)
+	if len(secGroups) > 0 {
+		opts["security_groups"] = secGroups
+	}
+
+	// port security is only sent when it's disabled, so clouds without
+	// the port-security extension keep working. A port without port
+	// security can't have security groups, not even the default one.
+	if !d.Get("port_security_enabled").(bool) {
+		if len(secGroups) > 0 || len(getPortAllowedAddressPairs(d)) > 0 {
+			return fmt.Errorf("security_groups and allowed_address_pairs can't be used when port_security_enabled is false.")
+		}
+		opts["port_security_enabled"] = false
+		opts["security_groups"] = []string{}
+	}
+
+	log.Printf("[INFO] Port create options: %v", opts)
+
+	port, err := createNeutronPort(client, opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(port.Id)
+
+	return resourcePortRead(d, meta)
+}
+
+func resourcePortRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	port, err := getNeutronPort(client, d.Id())
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] Port info: %v", port)
+
+	d.Set("network_id", port.NetworkId)
+	d.Set("name", port.Name)
+	d.Set("admin_state_up", port.AdminStateUp)
+	d.Set("mac_address", port.MACAddress)
+	d.Set("security_groups", port.SecurityGroups)
+	d.Set("vnic_type", port.VNICType)
+	d.Set("device_owner", port.DeviceOwner)
+	d.Set("device_id", port.DeviceId)
+	d.Set("tenant_id", port.TenantId)
+	d.Set("status", port.Status)
+
+	portSecurityEnabled := true
+	if port.PortSecurityEnabled != nil {
+		portSecurityEnabled = *port.PortSecurityEnabled
+	}
+	d.Set("port_security_enabled", portSecurityEnabled)
+
+	var fixedIPs []map[string]interface{}
+	for _, ip := range port.FixedIPs {
+		fixedIPs = append(fixedIPs, map[string]interface{}{
+			"subnet_id":  ip.SubnetId,
+			"ip_address": ip.IPAddress,
+		})
+	}
+	d.Set("fixed_ips", fixedIPs)
+
+	var pairs []map[string]interface{}
+	for _, p := range port.AllowedAddressPairs {
+		pairs = append(pairs, map[string]interface{}{
+			"ip_address":  p.IPAddress,
+			"mac_address": p.MACAddress,
+		})
+	}
+	d.Set("allowed_address_pairs", pairs)
+
+	return nil
+}
+
+func resourcePortUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	opts := make(map[string]interface{})
+	if d.HasChange("name") {
+		opts["name"] = d.Get("name").(string)
+	}
+	if d.HasChange("admin_state_up") {
+		opts["admin_state_up"] = d.Get("admin_state_up").(bool)
+	}
+	if d.HasChange("fixed_ips") {
+		opts["fixed_ips"] = getPortFixedIPs(d)
+	}
+	if d.HasChange("security_groups") {
+		opts["security_groups"] = getPortSecurityGroups(d)
+	}
+	if d.HasChange("allowed_address_pairs") {
+		opts["allowed_address_pairs"] = getPortAllowedAddressPairs(d)
+	}
+	if d.HasChange("port_security_enabled") {
+		enabled := d.Get("port_security_enabled").(bool)
+		opts["port_security_enabled"] = enabled
+		if !enabled {
+			opts["security_groups"] = []string{}
+			opts["allowed_address_pairs"] = []NeutronAddressPair{}
+		}
+	}
+	if d.HasChange("vnic_type") {
+		opts["binding:vnic_type"] = d.Get("vnic_type").(string)
+	}
+	if d.HasChange("device_owner") {
+		opts["device_owner"] = d.Get("device_owner").(string)
+	}
+	if d.HasChange("device_id") {
+		opts["device_id"] = d.Get("device_id").(string)
+	}
+
+	if len(opts) > 0 {
+		log.Printf("[INFO] Port update options: %v", opts)
+
+		if err := updateNeutronPort(client, d.Id(), opts); err != nil {
+			return err
+		}
+	}
+
+	return resourcePortRead(d, meta)
+}
+
+func resourcePortDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = ports.Delete(client, d.Id()).ExtractErr()
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error deleting port %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+func getPortFixedIPs(d *schema.ResourceData) []NeutronFixedIP {
+	fixedIPs := []NeutronFixedIP{}
+	for _, v := range d.Get("fixed_ips").([]interface{}) {
+		ip := v.(map[string]interface{})
+		fixedIPs = append(fixedIPs, NeutronFixedIP{
+			SubnetId:  ip["subnet_id"].(string),
+			IPAddress: ip["ip_address"].(string),
+		})
+	}
+
+	return fixedIPs
+}
+
+func getPortSecurityGroups(d *schema.ResourceData) []string {
+	secGroups := []string{}
+	for _, v := range d.Get("security_groups").(*schema.Set).List() {
+		secGroups = append(secGroups, v.(string))
+	}
+
+	return secGroups
+}
+
+func getPortAllowedAddressPairs(d *schema.ResourceData) []NeutronAddressPair {
+	pairs := []NeutronAddressPair{}
+	for _, v := range d.Get("allowed_address_pairs").([]interface{}) {
+		p := v.(map[string]interface{})
+		pairs = append(pairs, NeutronAddressPair{
+			IPAddress:  p["ip_address"].(string),
+			MACAddress: p["mac_address"].(string),
+		})
+	}
+
+	return pairs
+}
@@ -0,0 +1,124 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNetworkingV2Port(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2PortDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2Port,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_port.accept_test", "fixed_ips.0.ip_address", "192.168.199.23"),
+					resource.TestCheckResourceAttr("openstack_port.accept_test", "allowed_address_pairs.0.ip_address", "192.168.199.10"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNetworkingV2Port_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_port.accept_test", "fixed_ips.0.ip_address", "192.168.199.24"),
+					resource.TestCheckResourceAttr("openstack_port.accept_test", "allowed_address_pairs.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetworkingV2Port_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2PortDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2Port,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_port.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2PortDestroy(s *terraform.State) error {
+	networkClient, err := testNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_port" {
+			continue
+		}
+
+		_, err := getNeutronPort(networkClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Port still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccNetworkingV2Port_network = fmt.Sprintf(`
+	resource "openstack_network" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}
+
+	resource "openstack_subnet" "accept_test" {
+		region = "%s"
+		network_id = "${openstack_network.accept_test.id}"
+		cidr = "192.168.199.0/24"
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
+
+var testAccNetworkingV2Port = fmt.Sprintf(`
+	%s
+
+	resource "openstack_port" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		network_id = "${openstack_network.accept_test.id}"
+
+		fixed_ips {
+			subnet_id = "${openstack_subnet.accept_test.id}"
+			ip_address = "192.168.199.23"
+		}
+
+		allowed_address_pairs {
+			ip_address = "192.168.199.10"
+		}
+	}`,
+	testAccNetworkingV2Port_network,
+	OS_REGION_NAME,
+)
+
+var testAccNetworkingV2Port_update = fmt.Sprintf(`
+	%s
+
+	resource "openstack_port" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		network_id = "${openstack_network.accept_test.id}"
+
+		fixed_ips {
+			subnet_id = "${openstack_subnet.accept_test.id}"
+			ip_address = "192.168.199.24"
+		}
+	}`,
+	testAccNetworkingV2Port_network,
+	OS_REGION_NAME,
+)
@@ -0,0 +1,369 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/extensions/layer3/routers"
+)
+
+func resourceRouter() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRouterCreate,
+		Read:   resourceRouterRead,
+		Update: resourceRouterUpdate,
+		Delete: resourceRouterDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"admin_state_up": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			// neutron only allows these to change while the router is
+			// down, so changing them replaces the router instead
+			"distributed": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"ha": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"external_gateway_info": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"network_id": &schema.Schema{
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"enable_snat": &schema.Schema{
+							Type:     schema.TypeBool,
+							Optional: true,
+							Default:  true,
+						},
+						"external_fixed_ips": &schema.Schema{
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"subnet_id": &schema.Schema{
+										Type:     schema.TypeString,
+										Optional: true,
+										Computed: true,
+									},
+									"ip_address": &schema.Schema{
+										Type:         schema.TypeString,
+										Optional:     true,
+										Computed:     true,
+										ValidateFunc: validateIPAddress,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+
+			"routes": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination_cidr": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCIDR,
+						},
+						"next_hop": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateIPAddress,
+						},
+					},
+				},
+			},
+
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			// read-only / exported
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceRouterCreate(d *schema.ResourceData, meta interface{}) error {
+	// neutron only accepts next hops on subnets the router is connected
+	// to, and interfaces can only be added once the router exists
+	if len(d.Get("routes").([]interface{})) > 0 {
+		return fmt.Errorf("Routes can't be set when a router is created, since their next hops must be on a subnet of one of the router's interfaces. Create the router and its interfaces first, then add the routes.")
+	}
+
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	opts := map[string]interface{}{
+		"name":           d.Get("name").(string),
+		"admin_state_up": d.Get("admin_state_up").(bool),
+	}
+
+	// distributed and ha are admin-only, so only send them when enabled
+	if v, ok := d.GetOk("distributed"); ok {
+		opts["distributed"] = v.(bool)
+	}
+	if v, ok := d.GetOk("ha"); ok {
+		opts["ha"] = v.(bool)
+	}
+	if v := d.Get("tenant_id").(string); v != "" {
+		opts["tenant_id"] = v
+	}
+	if gw := getRouterGatewayInfo(d); gw != nil {
+		opts["external_gateway_info"] = gw
+	}
+
+	log.Printf("[INFO] Router create options: %v", opts)
+
+	router, err := createNeutronRouter(client, opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(router.Id)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"BUILD", "PENDING_CREATE", "PENDING_UPDATE"},
+		Target:     []string{"ACTIVE", "DOWN"},
+		Refresh:    waitForRouterState(client, router.Id),
+		Timeout:    10 * time.Minute,
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return err
+	}
+
+	return resourceRouterRead(d, meta)
+}
+
+func resourceRouterRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	router, err := getNeutronRouter(client, d.Id())
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] Router info: %v", router)
+
+	d.Set("name", router.Name)
+	d.Set("admin_state_up", router.AdminStateUp)
+	d.Set("distributed", router.Distributed)
+	d.Set("ha", router.HA)
+	d.Set("tenant_id", router.TenantId)
+	d.Set("status", router.Status)
+
+	var gateway []map[string]interface{}
+	if gw := router.ExternalGatewayInfo; gw != nil && gw.NetworkId != "" {
+		var fixedIPs []map[string]interface{}
+		for _, ip := range gw.ExternalFixedIPs {
+			fixedIPs = append(fixedIPs, map[string]interface{}{
+				"subnet_id":  ip.SubnetId,
+				"ip_address": ip.IPAddress,
+			})
+		}
+
+		enableSNAT := true
+		if gw.EnableSNAT != nil {
+			enableSNAT = *gw.EnableSNAT
+		}
+
+		gateway = append(gateway, map[string]interface{}{
+			"network_id":         gw.NetworkId,
+			"enable_snat":        enableSNAT,
+			"external_fixed_ips": fixedIPs,
+		})
+	}
+	d.Set("external_gateway_info", gateway)
+
+	var routes []map[string]interface{}
+	for _, r := range router.Routes {
+		routes = append(routes, map[string]interface{}{
+			"destination_cidr": r.DestinationCIDR,
+			"next_hop":         r.NextHop,
+		})
+	}
+	d.Set("routes", routes)
+
+	return nil
+}
+
+func resourceRouterUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	opts := make(map[string]interface{})
+	if d.HasChange("name") {
+		opts["name"] = d.Get("name").(string)
+	}
+	if d.HasChange("admin_state_up") {
+		opts["admin_state_up"] = d.Get("admin_state_up").(bool)
+	}
+	if d.HasChange("external_gateway_info") {
+		// an empty object clears the gateway
+		if gw := getRouterGatewayInfo(d); gw != nil {
+			opts["external_gateway_info"] = gw
+		} else {
+			opts["external_gateway_info"] = map[string]interface{}{}
+		}
+	}
+	if d.HasChange("routes") {
+		opts["routes"] = getRouterRoutes(d)
+	}
+
+	if len(opts) > 0 {
+		log.Printf("[INFO] Router update options: %v", opts)
+
+		if err := updateNeutronRouter(client, d.Id(), opts); err != nil {
+			return err
+		}
+	}
+
+	return resourceRouterRead(d, meta)
+}
+
+func resourceRouterDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	// a router can't be deleted while it still has interfaces, which
+	// are usually being removed at the same time
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		err := routers.Delete(client, d.Id()).ExtractErr()
+		if err == nil {
+			return nil
+		}
+
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok {
+			switch httpStatus.Actual {
+			case 404:
+				return nil
+			case 409:
+				log.Printf("[INFO] Router %s is in use, retrying: %v", d.Id(), err)
+				return resource.RetryableError(err)
+			}
+		}
+
+		return resource.NonRetryableError(err)
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error deleting router %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+func getRouterGatewayInfo(d *schema.ResourceData) *NeutronGatewayInfo {
+	v := d.Get("external_gateway_info").([]interface{})
+	if len(v) == 0 {
+		return nil
+	}
+
+	gw := v[0].(map[string]interface{})
+	info := &NeutronGatewayInfo{
+		NetworkId: gw["network_id"].(string),
+	}
+
+	// enable_snat is admin-only, so it's only sent when it's disabled
+	// or has been changed, leaving regular users with the default
+	enableSNAT := gw["enable_snat"].(bool)
+	if !enableSNAT || (d.Id() != "" && d.HasChange("external_gateway_info.0.enable_snat")) {
+		info.EnableSNAT = &enableSNAT
+	}
+
+	for _, v := range gw["external_fixed_ips"].([]interface{}) {
+		ip := v.(map[string]interface{})
+		info.ExternalFixedIPs = append(info.ExternalFixedIPs, NeutronFixedIP{
+			SubnetId:  ip["subnet_id"].(string),
+			IPAddress: ip["ip_address"].(string),
+		})
+	}
+
+	return info
+}
+
+func getRouterRoutes(d *schema.ResourceData) []NeutronHostRoute {
+	routes := []NeutronHostRoute{}
+	for _, v := range d.Get("routes").([]interface{}) {
+		r := v.(map[string]interface{})
+		routes = append(routes, NeutronHostRoute{
+			DestinationCIDR: r["destination_cidr"].(string),
+			NextHop:         r["next_hop"].(string),
+		})
+	}
+
+	return routes
+}
+
+func waitForRouterState(client *gophercloud.ServiceClient, routerId string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		router, err := getNeutronRouter(client, routerId)
+		if err != nil {
+			return nil, "", err
+		}
+
+		log.Printf("[DEBUG] Router %s status: %s", routerId, router.Status)
+		return router, router.Status, nil
+	}
+}
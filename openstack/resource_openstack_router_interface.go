@@ -0,0 +1,262 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/ports"
+)
+
+func resourceRouterInterface() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceRouterInterfaceCreate,
+		Read:   resourceRouterInterfaceRead,
+		Update: nil,
+		Delete: resourceRouterInterfaceDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"router_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"subnet_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"port_id"},
+			},
+
+			"port_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"subnet_id"},
+			},
+		},
+	}
+}
+
+func resourceRouterInterfaceCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	routerId := d.Get("router_id").(string)
+
+	opts := make(map[string]string)
+	if v := d.Get("subnet_id").(string); v != "" {
+		opts["subnet_id"] = v
+	} else if v := d.Get("port_id").(string); v != "" {
+		opts["port_id"] = v
+	} else {
+		return fmt.Errorf("One of subnet_id or port_id is required.")
+	}
+
+	log.Printf("[INFO] Router interface create options: %v", opts)
+
+	iface, err := updateNeutronRouterInterface(client, routerId, "add_router_interface", opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(iface.PortId)
+
+	// new ports start out DOWN, so DOWN is only accepted when the port
+	// can't become ACTIVE
+	target := []string{"ACTIVE"}
+	pending := []string{"BUILD", "DOWN"}
+	if routerInterfaceStaysDown(client, routerId) {
+		log.Printf("[INFO] Router %s is down or not hosted by an L3 agent, not waiting for port %s to become active", routerId, iface.PortId)
+		target = []string{"ACTIVE", "DOWN"}
+		pending = []string{"BUILD"}
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     target,
+		Refresh:    waitForPortState(client, iface.PortId),
+		Timeout:    5 * time.Minute,
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for router interface port %s to become active: %v", iface.PortId, err)
+	}
+
+	return resourceRouterInterfaceRead(d, meta)
+}
+
+// routerInterfaceStaysDown reports whether the ports of a router stay DOWN,
+// because the router is administratively down or no L3 agent hosts it.
+// The L3 agents can only be listed by admins, so other users always wait
+// for interfaces to become ACTIVE.
+func routerInterfaceStaysDown(client *gophercloud.ServiceClient, routerId string) bool {
+	router, err := getNeutronRouter(client, routerId)
+	if err != nil {
+		log.Printf("[INFO] Unable to retrieve router %s: %v", routerId, err)
+		return false
+	}
+
+	if !router.AdminStateUp {
+		return true
+	}
+
+	agents, err := getNeutronRouterL3Agents(client, routerId)
+	if err != nil {
+		log.Printf("[INFO] Unable to list the L3 agents of router %s: %v", routerId, err)
+		return false
+	}
+
+	return len(agents) == 0
+}
+
+func resourceRouterInterfaceRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	port, err := ports.Get(client, d.Id()).Extract()
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] Router interface port info: %v", port)
+
+	// the port is no longer attached to the router. router_id is
+	// only empty when the interface is being imported by port ID
+	routerId := d.Get("router_id").(string)
+	if routerId != "" && port.DeviceID != routerId {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("router_id", port.DeviceID)
+	d.Set("port_id", port.ID)
+
+	return nil
+}
+
+func resourceRouterInterfaceDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	routerId := d.Get("router_id").(string)
+	opts := map[string]string{
+		"port_id": d.Id(),
+	}
+
+	// neutron refuses to remove an interface while floating IPs are
+	// associated with addresses behind it, which is routine when the
+	// floating IPs are being released at the same time
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		_, err := updateNeutronRouterInterface(client, routerId, "remove_router_interface", opts)
+		if err == nil {
+			return nil
+		}
+
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok {
+			switch httpStatus.Actual {
+			case 404:
+				return nil
+			case 409:
+				log.Printf("[INFO] Router interface %s is in use, retrying: %v", d.Id(), err)
+				return resource.RetryableError(err)
+			}
+		}
+
+		return resource.NonRetryableError(err)
+	})
+
+	if err != nil {
+		fips, ferr := getRouterInterfaceFloatingIPs(client, routerId, d.Id())
+		if ferr != nil {
+			log.Printf("[INFO] Unable to determine the floating IPs of router interface %s: %v", d.Id(), ferr)
+		}
+		if len(fips) > 0 {
+			return fmt.Errorf("%v\n\nRouter interface %s is still used by floating IPs: %s",
+				err, d.Id(), strings.Join(formatFloatingIPs(fips), ", "))
+		}
+		return err
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"ACTIVE", "BUILD", "DOWN"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForPortState(client, d.Id()),
+		Timeout:    5 * time.Minute,
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for router interface port %s to be deleted: %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+// getRouterInterfaceFloatingIPs returns the floating IPs of a router whose
+// fixed addresses are on the subnets of one of its interfaces.
+func getRouterInterfaceFloatingIPs(client *gophercloud.ServiceClient, routerId, portId string) ([]NeutronFloatingIP, error) {
+	port, err := ports.Get(client, portId).Extract()
+	if err != nil {
+		return nil, err
+	}
+
+	var cidrs []*net.IPNet
+	for _, ip := range port.FixedIPs {
+		subnet, err := getNeutronSubnet(client, ip.SubnetID)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, cidr, err := net.ParseCIDR(subnet.CIDR); err == nil {
+			cidrs = append(cidrs, cidr)
+		}
+	}
+
+	fips, err := getRouterFloatingIPs(client, routerId)
+	if err != nil {
+		return nil, err
+	}
+
+	var used []NeutronFloatingIP
+	for _, fip := range fips {
+		ip := net.ParseIP(fip.FixedIPAddress)
+		for _, cidr := range cidrs {
+			if ip != nil && cidr.Contains(ip) {
+				used = append(used, fip)
+				break
+			}
+		}
+	}
+
+	return used, nil
+}
@@ -0,0 +1,98 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/rackspace/gophercloud/openstack/networking/v2/ports"
+)
+
+func TestAccNetworkingV2RouterInterface(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2RouterInterfaceDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2RouterInterface,
+				Check:  testAccCheckNetworkingV2RouterInterfaceExists("openstack_router_interface.accept_test"),
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2RouterInterfaceExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		networkClient, err := testNetworkClient()
+		if err != nil {
+			return err
+		}
+
+		port, err := ports.Get(networkClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if port.DeviceID != rs.Primary.Attributes["router_id"] {
+			return fmt.Errorf("Port %s is attached to %s, not the router.", port.ID, port.DeviceID)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckNetworkingV2RouterInterfaceDestroy(s *terraform.State) error {
+	networkClient, err := testNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_router_interface" {
+			continue
+		}
+
+		_, err := ports.Get(networkClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Router interface still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccNetworkingV2RouterInterface = fmt.Sprintf(`
+	resource "openstack_router" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}
+
+	resource "openstack_network" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}
+
+	resource "openstack_subnet" "accept_test" {
+		region = "%s"
+		network_id = "${openstack_network.accept_test.id}"
+		cidr = "192.168.199.0/24"
+	}
+
+	resource "openstack_router_interface" "accept_test" {
+		region = "%s"
+		router_id = "${openstack_router.accept_test.id}"
+		subnet_id = "${openstack_subnet.accept_test.id}"
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
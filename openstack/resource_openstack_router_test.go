@@ -0,0 +1,146 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNetworkingV2Router(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2RouterDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2Router,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_router.accept_test", "name", "accept_test"),
+					resource.TestCheckResourceAttr("openstack_router.accept_test", "status", "ACTIVE"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNetworkingV2Router_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_router.accept_test", "name", "accept_test_updated"),
+				),
+			},
+		},
+	})
+}
+
+// routes need an interface on the next hop's subnet, so they are added
+// once the interface exists
+func TestAccNetworkingV2Router_routes(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2RouterDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccNetworkingV2Router_routes, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_router.accept_test", "routes.#", "0"),
+				),
+			},
+			resource.TestStep{
+				Config: fmt.Sprintf(testAccNetworkingV2Router_routes, `
+		routes {
+			destination_cidr = "10.10.0.0/16"
+			next_hop = "192.168.199.254"
+		}`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_router.accept_test", "routes.#", "1"),
+					resource.TestCheckResourceAttr("openstack_router.accept_test", "routes.0.destination_cidr", "10.10.0.0/16"),
+					resource.TestCheckResourceAttr("openstack_router.accept_test", "routes.0.next_hop", "192.168.199.254"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetworkingV2Router_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2RouterDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2Router,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_router.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2RouterDestroy(s *terraform.State) error {
+	networkClient, err := testNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_router" {
+			continue
+		}
+
+		_, err := getNeutronRouter(networkClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Router still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccNetworkingV2Router = fmt.Sprintf(`
+	resource "openstack_router" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}`,
+	OS_REGION_NAME,
+)
+
+var testAccNetworkingV2Router_update = fmt.Sprintf(`
+	resource "openstack_router" "accept_test" {
+		region = "%s"
+		name = "accept_test_updated"
+	}`,
+	OS_REGION_NAME,
+)
+
+// the routes of the router are filled in with a second Sprintf
+var testAccNetworkingV2Router_routes = fmt.Sprintf(`
+	resource "openstack_router" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		%%s
+	}
+
+	resource "openstack_network" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}
+
+	resource "openstack_subnet" "accept_test" {
+		region = "%s"
+		network_id = "${openstack_network.accept_test.id}"
+		cidr = "192.168.199.0/24"
+	}
+
+	resource "openstack_router_interface" "accept_test" {
+		region = "%s"
+		router_id = "${openstack_router.accept_test.id}"
+		subnet_id = "${openstack_subnet.accept_test.id}"
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
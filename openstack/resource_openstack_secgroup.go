@@ -4,11 +4,18 @@ import (
 	"bytes"
 	"fmt"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
 	"github.com/rackspace/gophercloud"
 	"github.com/rackspace/gophercloud/openstack/compute/v2/extensions/secgroups"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/extensions/security/groups"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/extensions/security/rules"
+	"github.com/rackspace/gophercloud/pagination"
 )
 
 func resourceSecgroup() *schema.Resource {
@@ -17,6 +24,9 @@ func resourceSecgroup() *schema.Resource {
 		Read:   resourceSecgroupRead,
 		Update: resourceSecgroupUpdate,
 		Delete: resourceSecgroupDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSecgroupImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"region": &schema.Schema{
@@ -35,6 +45,30 @@ func resourceSecgroup() *schema.Resource {
 				Required: true,
 			},
 
+			// network_service specifies which network provider to use
+			// Either "nova-network" or "neutron"
+			"network_service": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "nova-network",
+			},
+
+			// force_detach removes the group from any instances still
+			// using it before it is deleted
+			"force_detach": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+			},
+
+			// delete_default_rules removes the egress rules neutron
+			// creates for every new security group
+			"delete_default_rules": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
 			"rule": &schema.Schema{
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -47,20 +81,35 @@ func resourceSecgroup() *schema.Resource {
 							Computed: true,
 						},
 						"from_port": &schema.Schema{
-							Type:     schema.TypeInt,
-							Required: true,
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validateSecgroupRulePort,
 						},
 						"to_port": &schema.Schema{
-							Type:     schema.TypeInt,
-							Required: true,
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validateSecgroupRulePort,
 						},
 						"protocol": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateSecgroupRuleProtocol,
 						},
 						"cidr": &schema.Schema{
-							Type:     schema.TypeString,
-							Required: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateSecgroupRuleCIDR,
+						},
+						"direction": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "ingress",
+							ValidateFunc: validateSecgroupRuleDirection,
+						},
+						"ethertype": &schema.Schema{
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validateSecgroupRuleEtherType,
 						},
 						"source_group": &schema.Schema{
 							Type:     schema.TypeString,
@@ -93,44 +142,83 @@ func resourceSecgroup() *schema.Resource {
 }
 
 func resourceSecgroupCreate(d *schema.ResourceData, meta interface{}) error {
-	client, err := getClient("compute", d, meta)
-	if err != nil {
-		return err
+	// the rules are refreshed from the API below, so save the
+	// configured rules first
+	rs := d.Get("rule").(*schema.Set)
+
+	// check the rules before anything is created
+	for _, rule := range rs.List() {
+		if err := validateSecgroupRule(rule.(map[string]interface{})); err != nil {
+			return err
+		}
 	}
 
-	secgroupName := d.Get("name").(string)
-	secgroupDescription := d.Get("description").(string)
+	var sgID string
+	networkService := d.Get("network_service")
+	if networkService == "neutron" {
+		client, err := getClient("network", d, meta)
+		if err != nil {
+			return err
+		}
 
-	opts := &secgroups.CreateOpts{
-		Name:        secgroupName,
-		Description: secgroupDescription,
-	}
+		newSG, err := createNeutronSecgroup(client, d)
+		if err != nil {
+			return err
+		}
 
-	newSG, err := secgroups.Create(client, opts).Extract()
-	if err != nil {
-		return err
-	}
+		sgID = newSG.ID
+		d.SetId(sgID)
 
-	d.SetId(newSG.ID)
-	if err := setSecgroupDetails(client, newSG.ID, d); err != nil {
-		return err
+		if d.Get("delete_default_rules").(bool) {
+			if err := deleteNeutronSecgroupDefaultRules(client, sgID); err != nil {
+				return resourceSecgroupRollback(d, meta, err)
+			}
+		}
+
+		if err := setNeutronSecgroupDetails(client, sgID, d); err != nil {
+			return resourceSecgroupRollback(d, meta, err)
+		}
+	} else {
+		client, err := getClient("compute", d, meta)
+		if err != nil {
+			return err
+		}
+
+		secgroupName := d.Get("name").(string)
+		secgroupDescription := d.Get("description").(string)
+
+		opts := &secgroups.CreateOpts{
+			Name:        secgroupName,
+			Description: secgroupDescription,
+		}
+
+		newSG, err := secgroups.Create(client, opts).Extract()
+		if err != nil {
+			return err
+		}
+
+		sgID = newSG.ID
+		d.SetId(sgID)
+		if err := setSecgroupDetails(client, sgID, d); err != nil {
+			return resourceSecgroupRollback(d, meta, err)
+		}
 	}
 
 	// if any rules exist...
-	if rs := d.Get("rule").(*schema.Set); rs.Len() > 0 {
+	if rs.Len() > 0 {
 		// create an empty schema set to hold all rules
 		rules := &schema.Set{
 			F: resourceSecgroupRuleHash,
 		}
 
 		// loop through each rule and create it
+		// only rules that were created are saved, with their IDs
 		for _, rule := range rs.List() {
-			err := resourceSecgroupRuleCreate(d, meta, newSG.ID, rule.(map[string]interface{}))
+			if err := resourceSecgroupRuleCreate(d, meta, sgID, rule.(map[string]interface{})); err != nil {
+				return resourceSecgroupRollback(d, meta, err)
+			}
 			rules.Add(rule)
 			d.Set("rule", rules)
-			if err != nil {
-				return err
-			}
 		}
 	}
 
@@ -138,7 +226,60 @@ func resourceSecgroupCreate(d *schema.ResourceData, meta interface{}) error {
 
 }
 
+// resourceSecgroupImport imports nova security groups by ID, and
+// neutron security groups as neutron/<ID>.
+func resourceSecgroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	d.Set("network_service", "nova-network")
+	if strings.HasPrefix(d.Id(), "neutron/") {
+		d.Set("network_service", "neutron")
+		d.SetId(strings.TrimPrefix(d.Id(), "neutron/"))
+	}
+
+	d.Set("delete_default_rules", false)
+	d.Set("force_detach", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceSecgroupRollback deletes a partially created security group,
+// along with any rules in it. If that fails too, the group and the rules
+// that were created stay in the state so the next apply can resume.
+func resourceSecgroupRollback(d *schema.ResourceData, meta interface{}, err error) error {
+	log.Printf("[INFO] Rolling back security group %s: %v", d.Id(), err)
+
+	// nothing can be using a group that was just created, so don't wait
+	// long for it to be released
+	if rerr := destroySecgroup(d, meta, 30*time.Second); rerr != nil {
+		return fmt.Errorf("%v\n\nUnable to roll back security group %s: %v", err, d.Id(), rerr)
+	}
+
+	d.SetId("")
+
+	return err
+}
+
 func resourceSecgroupRuleCreate(d *schema.ResourceData, meta interface{}, sgID string, rule map[string]interface{}) error {
+	if d.Get("network_service") == "neutron" {
+		client, err := getClient("network", d, meta)
+		if err != nil {
+			return err
+		}
+
+		newRule, err := createNeutronSecgroupRule(client, sgID, rule)
+		if err != nil {
+			return err
+		}
+
+		rule["id"] = newRule.ID
+		rule["parent_group_id"] = newRule.SecGroupID
+
+		return nil
+	}
+
+	if rule["direction"].(string) == "egress" {
+		return fmt.Errorf("Egress rules require the neutron network_service.")
+	}
+
 	client, err := getClient("compute", d, meta)
 	if err != nil {
 		return err
@@ -171,6 +312,15 @@ func resourceSecgroupRuleCreate(d *schema.ResourceData, meta interface{}, sgID s
 }
 
 func resourceSecgroupRead(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("network_service") == "neutron" {
+		client, err := getClient("network", d, meta)
+		if err != nil {
+			return err
+		}
+
+		return setNeutronSecgroupDetails(client, d.Id(), d)
+	}
+
 	client, err := getClient("compute", d, meta)
 	if err != nil {
 		return err
@@ -184,22 +334,58 @@ func resourceSecgroupRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceSecgroupUpdate(d *schema.ResourceData, meta interface{}) error {
+	if d.HasChange("name") || d.HasChange("description") {
+		name := d.Get("name").(string)
+		description := d.Get("description").(string)
+
+		if d.Get("network_service") == "neutron" {
+			client, err := getClient("network", d, meta)
+			if err != nil {
+				return err
+			}
+
+			if err := updateNeutronSecgroup(client, d.Id(), name, description); err != nil {
+				return err
+			}
+		} else {
+			client, err := getClient("compute", d, meta)
+			if err != nil {
+				return err
+			}
+
+			opts := &secgroups.UpdateOpts{
+				Name:        name,
+				Description: description,
+			}
+
+			if _, err := secgroups.Update(client, d.Id(), opts).Extract(); err != nil {
+				return err
+			}
+		}
+	}
+
 	if d.HasChange("rule") {
 		o, n := d.GetChange("rule")
 		ors := o.(*schema.Set).Difference(n.(*schema.Set))
 		nrs := n.(*schema.Set).Difference(o.(*schema.Set))
 
-		// delete old rules
+		// check the new rules before anything is changed
+		for _, rule := range nrs.List() {
+			if err := validateSecgroupRule(rule.(map[string]interface{})); err != nil {
+				return err
+			}
+		}
+
+		// delete old rules, keeping the state in step with each deletion
+		rules := schema.NewSet(resourceSecgroupRuleHash, o.(*schema.Set).List())
 		for _, rule := range ors.List() {
 			if err := resourceSecgroupRuleDelete(d, meta, d.Get("id").(string), rule.(map[string]interface{})); err != nil {
 				return err
 			}
+			rules.Remove(rule)
+			d.Set("rule", rules)
 		}
 
-		// save the state of the current rules
-		rules := o.(*schema.Set).Intersection(n.(*schema.Set))
-		d.Set("rule", rules)
-
 		// create the new rules
 		for _, rule := range nrs.List() {
 			err := resourceSecgroupRuleCreate(d, meta, d.Get("id").(string), rule.(map[string]interface{}))
@@ -216,6 +402,79 @@ func resourceSecgroupUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceSecgroupDelete(d *schema.ResourceData, meta interface{}) error {
+	return destroySecgroup(d, meta, 10*time.Minute)
+}
+
+// destroySecgroup deletes a security group, retrying for up to timeout
+// while the group is in use.
+func destroySecgroup(d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	if d.Get("force_detach").(bool) {
+		if err := detachSecgroup(d, meta); err != nil {
+			return err
+		}
+	}
+
+	// a group can't be deleted while it's in use, which is routine when
+	// the instances using it are being destroyed at the same time
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		err := deleteSecgroup(d, meta)
+		if err == nil {
+			return nil
+		}
+
+		if secgroupInUse(err) {
+			log.Printf("[INFO] Security group %s is in use, retrying: %v", d.Id(), err)
+			return resource.RetryableError(err)
+		}
+
+		return resource.NonRetryableError(err)
+	})
+
+	if err != nil {
+		users, uerr := getSecgroupUsers(d, meta)
+		if uerr != nil {
+			log.Printf("[INFO] Unable to determine what uses security group %s: %v", d.Id(), uerr)
+		}
+		if len(users) > 0 {
+			return fmt.Errorf("%v\n\nSecurity group %s is still in use by: %s", err, d.Id(), strings.Join(users, ", "))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// secgroupInUse reports whether a delete failed because the group is still
+// in use. nova returns a 400 for this as well as for bad requests, so the
+// message has to be checked; neutron returns a 409 SecurityGroupInUse.
+func secgroupInUse(err error) bool {
+	httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError)
+	if !ok {
+		return false
+	}
+
+	body := string(httpStatus.Body)
+
+	switch httpStatus.Actual {
+	case 400:
+		return strings.Contains(body, "in use")
+	case 409:
+		return strings.Contains(body, "SecurityGroupInUse") || strings.Contains(body, "in use")
+	}
+
+	return false
+}
+
+func deleteSecgroup(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("network_service") == "neutron" {
+		client, err := getClient("network", d, meta)
+		if err != nil {
+			return err
+		}
+
+		return groups.Delete(client, d.Id()).ExtractErr()
+	}
+
 	client, err := getClient("compute", d, meta)
 	if err != nil {
 		return err
@@ -229,6 +488,14 @@ func resourceSecgroupDelete(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceSecgroupRuleDelete(d *schema.ResourceData, meta interface{}, sgID string, rule map[string]interface{}) error {
+	if d.Get("network_service") == "neutron" {
+		client, err := getClient("network", d, meta)
+		if err != nil {
+			return err
+		}
+
+		return rules.Delete(client, rule["id"].(string)).ExtractErr()
+	}
 
 	client, err := getClient("compute", d, meta)
 	if err != nil {
@@ -254,6 +521,76 @@ func setSecgroupDetails(client *gophercloud.ServiceClient, sID string, d *schema
 	d.Set("description", sg.Description)
 	d.Set("tenant_id", sg.TenantID)
 
+	// source groups are reported by name, but configured by ID
+	groupIDs, err := getSecgroupIDs(client)
+	if err != nil {
+		return err
+	}
+
+	var rules []interface{}
+	for _, r := range sg.Rules {
+		rule := map[string]interface{}{
+			"id":              r.ID,
+			"from_port":       r.FromPort,
+			"to_port":         r.ToPort,
+			"protocol":        r.IPProtocol,
+			"cidr":            r.IPRange.CIDR,
+			"source_group":    "",
+			"parent_group_id": r.ParentGroupID,
+			"direction":       "ingress",
+			"ethertype":       "",
+		}
+
+		if r.Group.Name != "" {
+			rule["source_group"] = getSecgroupRuleSourceGroup(groupIDs, sg, r)
+		}
+
+		rules = append(rules, rule)
+	}
+	log.Printf("[INFO] Security Group rules: %v", rules)
+	d.Set("rule", rules)
+
+	return nil
+}
+
+// getSecgroupIDs maps "<tenant ID>/<name>" to the ID of each nova
+// security group.
+func getSecgroupIDs(client *gophercloud.ServiceClient) (map[string]string, error) {
+	groupIDs := make(map[string]string)
+	err := secgroups.List(client).EachPage(func(page pagination.Page) (bool, error) {
+		sgList, err := secgroups.ExtractSecurityGroups(page)
+		if err != nil {
+			return false, err
+		}
+
+		for _, g := range sgList {
+			groupIDs[g.TenantID+"/"+g.Name] = g.ID
+		}
+		return true, nil
+	})
+
+	return groupIDs, err
+}
+
+func getSecgroupRuleSourceGroup(groupIDs map[string]string, sg *secgroups.SecurityGroup, r secgroups.Rule) string {
+	tenantID := r.Group.TenantID
+	if tenantID == "" {
+		tenantID = sg.TenantID
+	}
+
+	return groupIDs[tenantID+"/"+r.Group.Name]
+}
+
+// getSecgroupRuleState returns the state of the rule with the given ID,
+// or nil if the rule isn't in the state yet.
+func getSecgroupRuleState(d *schema.ResourceData, id string) map[string]interface{} {
+	for _, v := range d.Get("rule").(*schema.Set).List() {
+		rule := v.(map[string]interface{})
+		if rule["id"].(string) == id {
+			return rule
+		}
+	}
+
 	return nil
 }
 
@@ -265,5 +602,7 @@ func resourceSecgroupRuleHash(v interface{}) int {
 	buf.WriteString(fmt.Sprintf("%s-", m["protocol"].(string)))
 	buf.WriteString(fmt.Sprintf("%s-", m["cidr"].(string)))
 	buf.WriteString(fmt.Sprintf("%s-", m["source_group"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["direction"].(string)))
+	buf.WriteString(fmt.Sprintf("%s-", m["ethertype"].(string)))
 	return hashcode.String(buf.String())
 }
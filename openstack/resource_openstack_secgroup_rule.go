@@ -0,0 +1,227 @@
+package openstack
+
+import (
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud/openstack/compute/v2/extensions/secgroups"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/extensions/security/rules"
+)
+
+func resourceSecgroupRule() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSecgroupRuleResourceCreate,
+		Read:   resourceSecgroupRuleResourceRead,
+		Update: nil,
+		Delete: resourceSecgroupRuleResourceDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceSecgroupRuleResourceImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"security_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			// network_service specifies which network provider to use
+			// Either "nova-network" or "neutron"
+			"network_service": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  "nova-network",
+			},
+
+			"from_port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSecgroupRulePort,
+			},
+
+			"to_port": &schema.Schema{
+				Type:         schema.TypeInt,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSecgroupRulePort,
+			},
+
+			"protocol": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSecgroupRuleProtocol,
+			},
+
+			"cidr": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"source_group"},
+				ValidateFunc:  validateSecgroupRuleCIDR,
+			},
+
+			"source_group": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"cidr"},
+			},
+
+			"direction": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "ingress",
+				ValidateFunc: validateSecgroupRuleDirection,
+			},
+
+			"ethertype": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSecgroupRuleEtherType,
+			},
+		},
+	}
+}
+
+func resourceSecgroupRuleResourceCreate(d *schema.ResourceData, meta interface{}) error {
+	rule := map[string]interface{}{
+		"from_port":    d.Get("from_port").(int),
+		"to_port":      d.Get("to_port").(int),
+		"protocol":     d.Get("protocol").(string),
+		"cidr":         d.Get("cidr").(string),
+		"source_group": d.Get("source_group").(string),
+		"direction":    d.Get("direction").(string),
+		"ethertype":    d.Get("ethertype").(string),
+	}
+
+	if err := validateSecgroupRule(rule); err != nil {
+		return err
+	}
+
+	if err := resourceSecgroupRuleCreate(d, meta, d.Get("security_group_id").(string), rule); err != nil {
+		return err
+	}
+
+	d.SetId(rule["id"].(string))
+
+	return resourceSecgroupRuleResourceRead(d, meta)
+}
+
+func resourceSecgroupRuleResourceRead(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("network_service") == "neutron" {
+		client, err := getClient("network", d, meta)
+		if err != nil {
+			return err
+		}
+
+		r, err := rules.Get(client, d.Id()).Extract()
+		if err != nil {
+			if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+				d.SetId("")
+				return nil
+			}
+			return err
+		}
+		log.Printf("[INFO] Security Group Rule info: %v", r)
+
+		rule := map[string]interface{}{
+			"cidr":      r.RemoteIPPrefix,
+			"ethertype": d.Get("ethertype").(string),
+		}
+
+		d.Set("security_group_id", r.SecGroupID)
+		d.Set("direction", r.Direction)
+		d.Set("protocol", r.Protocol)
+		d.Set("cidr", r.RemoteIPPrefix)
+		d.Set("source_group", r.RemoteGroupID)
+		if getSecgroupRuleEtherType(rule) != r.EtherType {
+			d.Set("ethertype", r.EtherType)
+		}
+		if d.Get("from_port").(int) != -1 || r.PortRangeMin != 0 {
+			d.Set("from_port", r.PortRangeMin)
+		}
+		if d.Get("to_port").(int) != -1 || r.PortRangeMax != 0 {
+			d.Set("to_port", r.PortRangeMax)
+		}
+
+		return nil
+	}
+
+	client, err := getClient("compute", d, meta)
+	if err != nil {
+		return err
+	}
+
+	// nova has no call to get a single rule, so look it up in its group
+	sg, err := secgroups.Get(client, d.Get("security_group_id").(string)).Extract()
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	for _, r := range sg.Rules {
+		if r.ID != d.Id() {
+			continue
+		}
+		log.Printf("[INFO] Security Group Rule info: %v", r)
+
+		d.Set("direction", "ingress")
+		d.Set("from_port", r.FromPort)
+		d.Set("to_port", r.ToPort)
+		d.Set("protocol", r.IPProtocol)
+		d.Set("cidr", r.IPRange.CIDR)
+
+		if r.Group.Name != "" {
+			groupIDs, err := getSecgroupIDs(client)
+			if err != nil {
+				return err
+			}
+			d.Set("source_group", getSecgroupRuleSourceGroup(groupIDs, sg, r))
+		}
+
+		return nil
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+func resourceSecgroupRuleResourceDelete(d *schema.ResourceData, meta interface{}) error {
+	rule := map[string]interface{}{
+		"id": d.Id(),
+	}
+
+	return resourceSecgroupRuleDelete(d, meta, d.Get("security_group_id").(string), rule)
+}
+
+// resourceSecgroupRuleResourceImport accepts a neutron rule ID, or
+// <security group ID>/<rule ID> for nova rules.
+func resourceSecgroupRuleResourceImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "/", 2)
+	if len(parts) == 2 {
+		d.Set("network_service", "nova-network")
+		d.Set("security_group_id", parts[0])
+		d.SetId(parts[1])
+	} else {
+		d.Set("network_service", "neutron")
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
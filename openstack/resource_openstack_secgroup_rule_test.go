@@ -0,0 +1,195 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/extensions/security/rules"
+)
+
+func TestAccNetworkingV2SecgroupRule(t *testing.T) {
+	var rule rules.SecGroupRule
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2SecgroupRuleDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2SecgroupRule,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckNetworkingV2SecgroupRuleExists(
+						t, "openstack_secgroup_rule.accept_test_a", &rule),
+					resource.TestCheckResourceAttr(
+						"openstack_secgroup_rule.accept_test_a", "protocol", "tcp"),
+					resource.TestCheckResourceAttr(
+						"openstack_secgroup_rule.accept_test_b", "direction", "egress"),
+				),
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_secgroup_rule.accept_test_a",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccComputeV2SecgroupRule_importBasic(t *testing.T) {
+	steps := []resource.TestStep{
+		resource.TestStep{
+			Config: testAccComputeV2SecgroupRule,
+		},
+		resource.TestStep{
+			ResourceName:      "openstack_secgroup_rule.accept_test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+	}
+	steps[0].Check = testAccSetComputeV2SecgroupRuleImportId(&steps[1], "openstack_secgroup_rule.accept_test")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2SecgroupDestroy,
+		Steps:        steps,
+	})
+}
+
+// nova rules are imported as <security group id>/<rule id>
+func testAccSetComputeV2SecgroupRuleImportId(step *resource.TestStep, n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %v", n)
+		}
+
+		step.ImportStateId = rs.Primary.Attributes["security_group_id"] + "/" + rs.Primary.ID
+
+		return nil
+	}
+}
+
+func testNetworkClient() (*gophercloud.ServiceClient, error) {
+	config := testAccProvider.Meta().(*Config)
+	networkClient, err := config.networkingClient(OS_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("Problem getting client: %v", err)
+	}
+	return networkClient, nil
+}
+
+func testAccCheckNetworkingV2SecgroupRuleExists(t *testing.T, n string, rule *rules.SecGroupRule) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %v", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No ID is set")
+		}
+
+		networkClient, err := testNetworkClient()
+		if err != nil {
+			return err
+		}
+
+		found, err := rules.Get(networkClient, rs.Primary.ID).Extract()
+		if err != nil {
+			return err
+		}
+
+		if found.ID != rs.Primary.ID {
+			return fmt.Errorf("Security group rule not found: %v", found.ID)
+		}
+
+		*rule = *found
+
+		return nil
+	}
+}
+
+func testAccCheckNetworkingV2SecgroupRuleDestroy(s *terraform.State) error {
+	networkClient, err := testNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_secgroup_rule" {
+			continue
+		}
+
+		_, err := rules.Get(networkClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Security group rule still exists.")
+		}
+	}
+
+	return nil
+}
+
+// the two groups reference each other, which only works with
+// standalone rules
+var testAccNetworkingV2SecgroupRule = fmt.Sprintf(`
+	resource "openstack_secgroup" "accept_test_a" {
+		region = "%s"
+		name = "accept_test_a"
+		description = "accept_test_a"
+		network_service = "neutron"
+	}
+
+	resource "openstack_secgroup" "accept_test_b" {
+		region = "%s"
+		name = "accept_test_b"
+		description = "accept_test_b"
+		network_service = "neutron"
+	}
+
+	resource "openstack_secgroup_rule" "accept_test_a" {
+		region = "%s"
+		network_service = "neutron"
+		security_group_id = "${openstack_secgroup.accept_test_a.id}"
+		from_port = 22
+		to_port = 22
+		protocol = "tcp"
+		ethertype = "IPv4"
+		source_group = "${openstack_secgroup.accept_test_b.id}"
+	}
+
+	resource "openstack_secgroup_rule" "accept_test_b" {
+		region = "%s"
+		network_service = "neutron"
+		security_group_id = "${openstack_secgroup.accept_test_b.id}"
+		direction = "egress"
+		from_port = 22
+		to_port = 22
+		protocol = "tcp"
+		ethertype = "IPv4"
+		source_group = "${openstack_secgroup.accept_test_a.id}"
+	}`,
+	OS_REGION_NAME, OS_REGION_NAME, OS_REGION_NAME, OS_REGION_NAME,
+)
+
+var testAccComputeV2SecgroupRule = fmt.Sprintf(`
+	resource "openstack_secgroup" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		description = "accept_test"
+	}
+
+	resource "openstack_secgroup_rule" "accept_test" {
+		region = "%s"
+		security_group_id = "${openstack_secgroup.accept_test.id}"
+		from_port = 22
+		to_port = 22
+		protocol = "tcp"
+		cidr = "0.0.0.0/0"
+	}`,
+	OS_REGION_NAME, OS_REGION_NAME,
+)
@@ -0,0 +1,155 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/rackspace/gophercloud/openstack/compute/v2/extensions/secgroups"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/extensions/security/groups"
+)
+
+func TestAccComputeV2Secgroup_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckComputeV2SecgroupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccComputeV2Secgroup,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_secgroup.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNetworkingV2Secgroup_importBasic(t *testing.T) {
+	steps := []resource.TestStep{
+		resource.TestStep{
+			Config: testAccNetworkingV2Secgroup,
+		},
+		resource.TestStep{
+			ResourceName:      "openstack_secgroup.accept_test",
+			ImportState:       true,
+			ImportStateVerify: true,
+		},
+	}
+	steps[0].Check = testAccSetImportStateId(&steps[1], "openstack_secgroup.accept_test", "neutron/")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2SecgroupDestroy,
+		Steps:        steps,
+	})
+}
+
+// testAccSetImportStateId sets the ID an import step uses to the ID of a
+// resource created by an earlier step, with a prefix.
+func testAccSetImportStateId(step *resource.TestStep, n, prefix string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %v", n)
+		}
+
+		step.ImportStateId = prefix + rs.Primary.ID
+
+		return nil
+	}
+}
+
+func testAccCheckComputeV2SecgroupDestroy(s *terraform.State) error {
+	computeClient, err := testComputeClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_secgroup" {
+			continue
+		}
+
+		_, err := secgroups.Get(computeClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Security group still exists.")
+		}
+	}
+
+	return nil
+}
+
+func testAccCheckNetworkingV2SecgroupDestroy(s *terraform.State) error {
+	networkClient, err := testNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_secgroup" {
+			continue
+		}
+
+		_, err := groups.Get(networkClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Security group still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccComputeV2Secgroup = fmt.Sprintf(`
+	resource "openstack_secgroup" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		description = "accept_test"
+
+		rule {
+			protocol = "tcp"
+			from_port = 22
+			to_port = 22
+			cidr = "0.0.0.0/0"
+		}
+
+		rule {
+			protocol = "icmp"
+			from_port = -1
+			to_port = -1
+			cidr = "0.0.0.0/0"
+		}
+	}`,
+	OS_REGION_NAME,
+)
+
+var testAccNetworkingV2Secgroup = fmt.Sprintf(`
+	resource "openstack_secgroup" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		description = "accept_test"
+		network_service = "neutron"
+
+		rule {
+			protocol = "tcp"
+			from_port = 22
+			to_port = 22
+			cidr = "0.0.0.0/0"
+		}
+
+		rule {
+			direction = "egress"
+			protocol = "tcp"
+			from_port = 443
+			to_port = 443
+			cidr = "::/0"
+			ethertype = "IPv6"
+		}
+	}`,
+	OS_REGION_NAME,
+)
@@ -0,0 +1,369 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/subnets"
+)
+
+func resourceSubnet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSubnetCreate,
+		Read:   resourceSubnetRead,
+		Update: resourceSubnetUpdate,
+		Delete: resourceSubnetDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"network_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"cidr": &schema.Schema{
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateCIDR,
+			},
+
+			"ip_version": &schema.Schema{
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      4,
+				ValidateFunc: validateSubnetIPVersion,
+			},
+
+			"gateway_ip": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"no_gateway"},
+				ValidateFunc:  validateIPAddress,
+			},
+
+			"no_gateway": &schema.Schema{
+				Type:          schema.TypeBool,
+				Optional:      true,
+				ConflictsWith: []string{"gateway_ip"},
+			},
+
+			"allocation_pools": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateIPAddress,
+						},
+						"end": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateIPAddress,
+						},
+					},
+				},
+			},
+
+			"dns_nameservers": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"host_routes": &schema.Schema{
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"destination_cidr": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateCIDR,
+						},
+						"next_hop": &schema.Schema{
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateIPAddress,
+						},
+					},
+				},
+			},
+
+			"enable_dhcp": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ipv6_address_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSubnetIPv6Mode,
+			},
+
+			"ipv6_ra_mode": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSubnetIPv6Mode,
+			},
+
+			"tenant_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+func resourceSubnetCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	ipVersion := d.Get("ip_version").(int)
+	if ipVersion == 4 {
+		if _, ok := d.GetOk("ipv6_address_mode"); ok {
+			return fmt.Errorf("ipv6_address_mode can only be used with ip_version 6.")
+		}
+		if _, ok := d.GetOk("ipv6_ra_mode"); ok {
+			return fmt.Errorf("ipv6_ra_mode can only be used with ip_version 6.")
+		}
+	}
+
+	opts := map[string]interface{}{
+		"network_id":      d.Get("network_id").(string),
+		"name":            d.Get("name").(string),
+		"cidr":            d.Get("cidr").(string),
+		"ip_version":      ipVersion,
+		"enable_dhcp":     d.Get("enable_dhcp").(bool),
+		"dns_nameservers": getSubnetDNSNameservers(d),
+		"host_routes":     getSubnetHostRoutes(d),
+	}
+
+	if d.Get("no_gateway").(bool) {
+		opts["gateway_ip"] = nil
+	} else if v := d.Get("gateway_ip").(string); v != "" {
+		opts["gateway_ip"] = v
+	}
+	if pools := getSubnetAllocationPools(d); len(pools) > 0 {
+		opts["allocation_pools"] = pools
+	}
+	if v := d.Get("ipv6_address_mode").(string); v != "" {
+		opts["ipv6_address_mode"] = v
+	}
+	if v := d.Get("ipv6_ra_mode").(string); v != "" {
+		opts["ipv6_ra_mode"] = v
+	}
+	if v := d.Get("tenant_id").(string); v != "" {
+		opts["tenant_id"] = v
+	}
+
+	log.Printf("[INFO] Subnet create options: %v", opts)
+
+	subnet, err := createNeutronSubnet(client, opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(subnet.Id)
+	invalidateLookups(d, meta, "network")
+
+	return resourceSubnetRead(d, meta)
+}
+
+func resourceSubnetRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	subnet, err := getNeutronSubnet(client, d.Id())
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] Subnet info: %v", subnet)
+
+	d.Set("network_id", subnet.NetworkId)
+	d.Set("name", subnet.Name)
+	d.Set("cidr", subnet.CIDR)
+	d.Set("ip_version", subnet.IPVersion)
+	d.Set("enable_dhcp", subnet.EnableDHCP)
+	d.Set("dns_nameservers", subnet.DNSNameservers)
+	d.Set("ipv6_address_mode", subnet.IPv6AddressMode)
+	d.Set("ipv6_ra_mode", subnet.IPv6RAMode)
+	d.Set("tenant_id", subnet.TenantId)
+
+	if subnet.GatewayIP != nil {
+		d.Set("gateway_ip", *subnet.GatewayIP)
+		d.Set("no_gateway", false)
+	} else {
+		d.Set("gateway_ip", "")
+		d.Set("no_gateway", true)
+	}
+
+	var pools []map[string]interface{}
+	for _, p := range subnet.AllocationPools {
+		pools = append(pools, map[string]interface{}{
+			"start": p.Start,
+			"end":   p.End,
+		})
+	}
+	d.Set("allocation_pools", pools)
+
+	var routes []map[string]interface{}
+	for _, r := range subnet.HostRoutes {
+		routes = append(routes, map[string]interface{}{
+			"destination_cidr": r.DestinationCIDR,
+			"next_hop":         r.NextHop,
+		})
+	}
+	d.Set("host_routes", routes)
+
+	return nil
+}
+
+func resourceSubnetUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	opts := make(map[string]interface{})
+	if d.HasChange("name") {
+		opts["name"] = d.Get("name").(string)
+	}
+	if d.HasChange("no_gateway") || d.HasChange("gateway_ip") {
+		if d.Get("no_gateway").(bool) {
+			opts["gateway_ip"] = nil
+		} else if v := d.Get("gateway_ip").(string); v != "" {
+			opts["gateway_ip"] = v
+		}
+	}
+	if d.HasChange("allocation_pools") {
+		opts["allocation_pools"] = getSubnetAllocationPools(d)
+	}
+	if d.HasChange("dns_nameservers") {
+		opts["dns_nameservers"] = getSubnetDNSNameservers(d)
+	}
+	if d.HasChange("host_routes") {
+		opts["host_routes"] = getSubnetHostRoutes(d)
+	}
+	if d.HasChange("enable_dhcp") {
+		opts["enable_dhcp"] = d.Get("enable_dhcp").(bool)
+	}
+
+	if len(opts) > 0 {
+		log.Printf("[INFO] Subnet update options: %v", opts)
+
+		if err := updateNeutronSubnet(client, d.Id(), opts); err != nil {
+			return err
+		}
+	}
+
+	return resourceSubnetRead(d, meta)
+}
+
+func resourceSubnetDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("network", d, meta)
+	if err != nil {
+		return err
+	}
+
+	// a subnet can't be deleted while ports still have addresses on it
+	err = resource.Retry(5*time.Minute, func() *resource.RetryError {
+		err := subnets.Delete(client, d.Id()).ExtractErr()
+		if err == nil {
+			return nil
+		}
+
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok {
+			switch httpStatus.Actual {
+			case 404:
+				return nil
+			case 409:
+				log.Printf("[INFO] Subnet %s is in use, retrying: %v", d.Id(), err)
+				return resource.RetryableError(err)
+			}
+		}
+
+		return resource.NonRetryableError(err)
+	})
+
+	if err != nil {
+		return fmt.Errorf("Error deleting subnet %s: %v", d.Id(), err)
+	}
+
+	invalidateLookups(d, meta, "network")
+
+	return nil
+}
+
+func getSubnetAllocationPools(d *schema.ResourceData) []NeutronAllocationPool {
+	pools := []NeutronAllocationPool{}
+	for _, v := range d.Get("allocation_pools").([]interface{}) {
+		p := v.(map[string]interface{})
+		pools = append(pools, NeutronAllocationPool{
+			Start: p["start"].(string),
+			End:   p["end"].(string),
+		})
+	}
+
+	return pools
+}
+
+func getSubnetDNSNameservers(d *schema.ResourceData) []string {
+	nameservers := []string{}
+	for _, v := range d.Get("dns_nameservers").([]interface{}) {
+		nameservers = append(nameservers, v.(string))
+	}
+
+	return nameservers
+}
+
+func getSubnetHostRoutes(d *schema.ResourceData) []NeutronHostRoute {
+	routes := []NeutronHostRoute{}
+	for _, v := range d.Get("host_routes").([]interface{}) {
+		r := v.(map[string]interface{})
+		routes = append(routes, NeutronHostRoute{
+			DestinationCIDR: r["destination_cidr"].(string),
+			NextHop:         r["next_hop"].(string),
+		})
+	}
+
+	return routes
+}
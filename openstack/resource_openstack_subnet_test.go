@@ -0,0 +1,121 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccNetworkingV2Subnet(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2SubnetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2Subnet,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_subnet.accept_test", "gateway_ip", "192.168.199.1"),
+					resource.TestCheckResourceAttr("openstack_subnet.accept_test", "allocation_pools.0.start", "192.168.199.100"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccNetworkingV2Subnet_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_subnet.accept_test", "no_gateway", "true"),
+					resource.TestCheckResourceAttr("openstack_subnet.accept_test", "dns_nameservers.#", "2"),
+					resource.TestCheckResourceAttr("openstack_subnet.accept_test", "host_routes.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccNetworkingV2Subnet_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckNetworkingV2SubnetDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccNetworkingV2Subnet,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_subnet.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckNetworkingV2SubnetDestroy(s *terraform.State) error {
+	networkClient, err := testNetworkClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_subnet" {
+			continue
+		}
+
+		_, err := getNeutronSubnet(networkClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Subnet still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccNetworkingV2Subnet = fmt.Sprintf(`
+	resource "openstack_network" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}
+
+	resource "openstack_subnet" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		network_id = "${openstack_network.accept_test.id}"
+		cidr = "192.168.199.0/24"
+
+		allocation_pools {
+			start = "192.168.199.100"
+			end = "192.168.199.200"
+		}
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
+
+var testAccNetworkingV2Subnet_update = fmt.Sprintf(`
+	resource "openstack_network" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}
+
+	resource "openstack_subnet" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		network_id = "${openstack_network.accept_test.id}"
+		cidr = "192.168.199.0/24"
+		no_gateway = true
+		dns_nameservers = ["8.8.8.8", "8.8.4.4"]
+
+		allocation_pools {
+			start = "192.168.199.100"
+			end = "192.168.199.200"
+		}
+
+		host_routes {
+			destination_cidr = "10.0.0.0/8"
+			next_hop = "192.168.199.254"
+		}
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
@@ -17,8 +17,11 @@ func resourceVolume() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceVolumeCreate,
 		Read:   resourceVolumeRead,
-		Update: nil,
+		Update: resourceVolumeUpdate,
 		Delete: resourceVolumeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"region": &schema.Schema{
@@ -41,6 +44,7 @@ func resourceVolume() *schema.Resource {
 			"size": &schema.Schema{
 				Type:     schema.TypeInt,
 				Required: true,
+				ForceNew: true,
 			},
 
 			"volume_type": &schema.Schema{
@@ -49,6 +53,14 @@ func resourceVolume() *schema.Resource {
 				Computed: true,
 			},
 
+			// whether a retype may move the volume to another backend
+			"migration_policy": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "never",
+				ValidateFunc: validateVolumeMigrationPolicy,
+			},
+
 			"availability_zone": &schema.Schema{
 				Type:     schema.TypeString,
 				Optional: true,
@@ -84,6 +96,16 @@ func resourceVolume() *schema.Resource {
 				ForceNew: true,
 			},
 
+			"image": imageLookupSchema(),
+
+			// the volume is created empty and the backup restored onto it
+			"restore_from_backup_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"snapshot_id", "source_volume_id", "image_id", "image_name", "image"},
+			},
+
 			"metadata": &schema.Schema{
 				Type:     schema.TypeMap,
 				Optional: true,
@@ -153,9 +175,14 @@ func resourceVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 
 	// figure out the image
 	imageID := d.Get("image_id").(string)
-	imageName := d.Get("image_name").(string)
-	if imageName != "" {
-		imageID, err = getImageID(client, d)
+	_, hasImage := d.GetOk("image")
+	if d.Get("image_name").(string) != "" || hasImage {
+		computeClient, err := getClient("compute", d, meta)
+		if err != nil {
+			return err
+		}
+
+		imageID, err = getImageID(computeClient, d, meta)
 		if err != nil {
 			return err
 		}
@@ -172,6 +199,19 @@ func resourceVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 		metadata = nil
 	}
 
+	// the backup is restored onto an empty volume, so check it fits before
+	// creating one
+	if backupID := d.Get("restore_from_backup_id").(string); backupID != "" {
+		backup, err := getVolumeBackup(client, backupID)
+		if err != nil {
+			return fmt.Errorf("Error retrieving backup %s: %v", backupID, err)
+		}
+
+		if size := d.Get("size").(int); size < backup.Size {
+			return fmt.Errorf("Backup %s is %d GB, which doesn't fit on a %d GB volume.", backupID, backup.Size, size)
+		}
+	}
+
 	opts := &volumes.CreateOpts{
 		Availability: d.Get("availability_zone").(string),
 		Description:  d.Get("description").(string),
@@ -190,8 +230,8 @@ func resourceVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"BUILD"},
-		Target:     "available",
+		Pending:    []string{"BUILD", "creating", "downloading"},
+		Target:     []string{"available"},
 		Refresh:    waitForVolumeState(client, newVolume.ID),
 		Timeout:    30 * time.Minute,
 		Delay:      5 * time.Second,
@@ -204,6 +244,12 @@ func resourceVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(newVolume.ID)
 
+	if backupID := d.Get("restore_from_backup_id").(string); backupID != "" {
+		if err := restoreVolume(client, d, backupID); err != nil {
+			return err
+		}
+	}
+
 	if err := setVolumeDetails(client, newVolume.ID, d); err != nil {
 		return err
 	}
@@ -211,6 +257,49 @@ func resourceVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
+// restoreVolume restores a backup onto a new volume and waits for both
+// the volume and the backup to become available again.
+func restoreVolume(client *gophercloud.ServiceClient, d *schema.ResourceData, backupID string) error {
+	log.Printf("[INFO] Restoring backup %s onto volume %s", backupID, d.Id())
+
+	if _, err := restoreVolumeBackup(client, backupID, d.Id()); err != nil {
+		return fmt.Errorf("Error restoring backup %s: %v", backupID, err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"restoring-backup"},
+		Target:     []string{"available"},
+		Refresh:    waitForVolumeState(client, d.Id()),
+		Timeout:    2 * time.Hour,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for backup %s to be restored: %v", backupID, err)
+	}
+
+	stateConf = &resource.StateChangeConf{
+		Pending:    []string{"restoring"},
+		Target:     []string{"available"},
+		Refresh:    waitForVolumeBackupState(client, backupID),
+		Timeout:    10 * time.Minute,
+		Delay:      2 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for backup %s to become available: %v", backupID, err)
+	}
+
+	// cinder copies the name and description of the backed up volume
+	// onto the restored volume, so put the configured ones back
+	return updateVolume(client, d.Id(), map[string]interface{}{
+		"display_name":        d.Get("name").(string),
+		"display_description": d.Get("description").(string),
+	})
+}
+
 func resourceVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	client, err := getClient("block", d, meta)
 	if err != nil {
@@ -221,24 +310,117 @@ func resourceVolumeRead(d *schema.ResourceData, meta interface{}) error {
 		return err
 	}
 
+	// the migration policy is only used by updates, so imported
+	// volumes get the default
+	if d.Get("migration_policy").(string) == "" {
+		d.Set("migration_policy", "never")
+	}
+
 	return nil
 }
 
-/*
-// TODO: support extending volumes
+// resourceVolumeUpdate renames, retypes and replaces the metadata of a
+// volume in place. Changing the size still replaces the volume.
 func resourceVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 	client, err := getClient("block", d, meta)
 	if err != nil {
 		return err
 	}
 
+	opts := make(map[string]interface{})
+	if d.HasChange("name") {
+		opts["display_name"] = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		opts["display_description"] = d.Get("description").(string)
+	}
+
+	if len(opts) > 0 {
+		log.Printf("[INFO] Volume update options: %v", opts)
+
+		if err := updateVolume(client, d.Id(), opts); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata := make(map[string]string)
+		for k, v := range d.Get("metadata").(map[string]interface{}) {
+			metadata[k] = v.(string)
+		}
+
+		if err := updateVolumeMetadata(client, d.Id(), metadata); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("volume_type") {
+		if err := resourceVolumeRetype(client, d); err != nil {
+			return err
+		}
+	}
+
 	if err := setVolumeDetails(client, d.Id(), d); err != nil {
 		return err
 	}
 
 	return nil
 }
-*/
+
+// resourceVolumeRetype changes the volume type of a volume and waits for
+// any migration to finish. Attached volumes can be retyped when the
+// backend supports it.
+func resourceVolumeRetype(client *gophercloud.ServiceClient, d *schema.ResourceData) error {
+	newType := d.Get("volume_type").(string)
+	policy := d.Get("migration_policy").(string)
+
+	log.Printf("[INFO] Retyping volume %s to %s, migration policy %s", d.Id(), newType, policy)
+
+	if err := retypeVolume(client, d.Id(), newType, policy); err != nil {
+		return fmt.Errorf("Error retyping volume %s: %v", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"retyping", "migrating"},
+		Target:     []string{"available", "in-use"},
+		Refresh:    waitForVolumeRetype(client, d.Id()),
+		Timeout:    2 * time.Hour,
+		Delay:      5 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	v, err := stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for volume %s to be retyped: %v", d.Id(), err)
+	}
+
+	// cinder puts the volume back in its old state when a retype fails,
+	// for instance when it needs a migration and the policy is never
+	volume := v.(*VolumeMigration)
+	if !volumeTypeMatches(client, volume.VolumeType, newType) {
+		return fmt.Errorf(
+			"Volume %s is still of volume type %s. Retyping it to %s may need migration_policy = \"on-demand\", see the cinder logs for details.",
+			d.Id(), volume.VolumeType, newType)
+	}
+
+	return nil
+}
+
+// volumeTypeMatches reports whether the volume type cinder shows for a
+// volume, which is always a name, is the type given by name or ID.
+func volumeTypeMatches(client *gophercloud.ServiceClient, actual, want string) bool {
+	if actual == want {
+		return true
+	}
+
+	volumeType, err := getVolumeType(client, want)
+	if err != nil {
+		log.Printf("[INFO] Unable to look up volume type %s: %v", want, err)
+		return false
+	}
+
+	return actual == volumeType.Name || actual == volumeType.Id
+}
 
 func resourceVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 	client, err := getClient("block", d, meta)
@@ -277,7 +459,7 @@ func resourceVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 
 	stateConf := &resource.StateChangeConf{
 		Pending:    []string{"available"},
-		Target:     "DELETED",
+		Target:     []string{"DELETED"},
 		Refresh:    waitForVolumeState(client, d.Id()),
 		Timeout:    30 * time.Minute,
 		Delay:      5 * time.Second,
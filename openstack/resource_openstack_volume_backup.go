@@ -0,0 +1,196 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+)
+
+func resourceVolumeBackup() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVolumeBackupCreate,
+		Read:   resourceVolumeBackupRead,
+		Update: nil,
+		Delete: resourceVolumeBackupDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"volume_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"container": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"incremental": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			// force allows backups of attached volumes
+			"force": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			// read-only / exported
+			"size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"object_count": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVolumeBackupCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	opts := map[string]interface{}{
+		"volume_id":   d.Get("volume_id").(string),
+		"name":        d.Get("name").(string),
+		"description": d.Get("description").(string),
+	}
+	if v := d.Get("container").(string); v != "" {
+		opts["container"] = v
+	}
+	// incremental and force need backups API 2.0 or later,
+	// so only send them when they're used
+	if d.Get("incremental").(bool) {
+		opts["incremental"] = true
+	}
+	if d.Get("force").(bool) {
+		opts["force"] = true
+	}
+
+	log.Printf("[INFO] Backup create options: %v", opts)
+
+	backup, err := createVolumeBackup(client, opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(backup.Id)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     []string{"available"},
+		Refresh:    waitForVolumeBackupState(client, backup.Id),
+		Timeout:    2 * time.Hour,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return err
+	}
+
+	return resourceVolumeBackupRead(d, meta)
+}
+
+func resourceVolumeBackupRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	backup, err := getVolumeBackup(client, d.Id())
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] Backup info: %v", backup)
+
+	d.Set("volume_id", backup.VolumeId)
+	d.Set("name", backup.Name)
+	d.Set("description", backup.Description)
+	d.Set("container", backup.Container)
+	d.Set("incremental", backup.IsIncremental)
+	d.Set("size", backup.Size)
+	d.Set("status", backup.Status)
+	d.Set("object_count", backup.ObjectCount)
+	d.Set("created_at", backup.CreatedAt)
+
+	return nil
+}
+
+func resourceVolumeBackupDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := deleteVolumeBackup(client, d.Id()); err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error deleting backup %s: %v", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"available", "deleting"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForVolumeBackupState(client, d.Id()),
+		Timeout:    2 * time.Hour,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBlockStorageV1VolumeBackup(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeBackupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeBackup,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_volume_backup.accept_test", "status", "available"),
+					resource.TestCheckResourceAttr("openstack_volume.accept_test_restore", "status", "available"),
+					resource.TestCheckResourceAttr("openstack_volume.accept_test_restore", "name", "accept_test_restore"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockStorageV1VolumeBackup_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeBackupDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeBackup,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_volume_backup.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBlockStorageV1VolumeBackupDestroy(s *terraform.State) error {
+	blockClient, err := testBlockStorageClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_volume_backup" {
+			continue
+		}
+
+		_, err := getVolumeBackup(blockClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Backup still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccBlockStorageV1VolumeBackup = fmt.Sprintf(`
+	resource "openstack_volume" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		size = 1
+	}
+
+	resource "openstack_volume_backup" "accept_test" {
+		region = "%s"
+		volume_id = "${openstack_volume.accept_test.id}"
+		name = "accept_test"
+	}
+
+	resource "openstack_volume" "accept_test_restore" {
+		region = "%s"
+		name = "accept_test_restore"
+		size = 1
+		restore_from_backup_id = "${openstack_volume_backup.accept_test.id}"
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
@@ -0,0 +1,171 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+)
+
+func resourceVolumeImage() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVolumeImageCreate,
+		Read:   resourceVolumeImageRead,
+		Update: nil,
+		Delete: resourceVolumeImageDelete,
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"volume_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"image_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"disk_format": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "raw",
+				ForceNew: true,
+			},
+
+			"container_format": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "bare",
+				ForceNew: true,
+			},
+
+			// force allows uploads of attached volumes
+			"force": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			// read-only / exported
+			"image_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVolumeImageCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	volumeID := d.Get("volume_id").(string)
+	opts := map[string]interface{}{
+		"image_name":       d.Get("image_name").(string),
+		"disk_format":      d.Get("disk_format").(string),
+		"container_format": d.Get("container_format").(string),
+		"force":            d.Get("force").(bool),
+	}
+
+	log.Printf("[INFO] Volume image upload options: %v", opts)
+
+	upload, err := uploadVolumeImage(client, volumeID, opts)
+	if err != nil {
+		return fmt.Errorf("Error uploading volume %s to an image: %v", volumeID, err)
+	}
+
+	d.SetId(upload.ImageId)
+	invalidateLookups(d, meta, "image")
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"uploading"},
+		Target:     []string{"available", "in-use"},
+		Refresh:    waitForVolumeState(client, volumeID),
+		Timeout:    2 * time.Hour,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return fmt.Errorf("Error waiting for volume %s to be uploaded: %v", volumeID, err)
+	}
+
+	if err := resourceVolumeImageRead(d, meta); err != nil {
+		return err
+	}
+
+	// cinder puts the volume back when the upload fails, and glance
+	// keeps the image around in a killed state
+	if status := d.Get("status").(string); status != "active" {
+		return fmt.Errorf("Image %s is %s after uploading volume %s, see the cinder logs for details.", d.Id(), status, volumeID)
+	}
+
+	return nil
+}
+
+func resourceVolumeImageRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("image", d, meta)
+	if err != nil {
+		return err
+	}
+
+	image, err := getGlanceImage(client, d.Id())
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] Image info: %v", image)
+
+	d.Set("image_id", image.Id)
+	d.Set("image_name", image.Name)
+	d.Set("disk_format", image.DiskFormat)
+	d.Set("container_format", image.ContainerFormat)
+	d.Set("status", image.Status)
+	d.Set("size", image.Size)
+
+	return nil
+}
+
+func resourceVolumeImageDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("image", d, meta)
+	if err != nil {
+		return err
+	}
+
+	err = deleteGlanceImage(client, d.Id())
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); !ok || httpStatus.Actual != 404 {
+			return fmt.Errorf("Error deleting image %s: %v", d.Id(), err)
+		}
+	}
+
+	invalidateLookups(d, meta, "image")
+
+	return nil
+}
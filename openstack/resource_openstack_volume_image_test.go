@@ -0,0 +1,64 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBlockStorageV1VolumeImage(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeImageDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeImage,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_volume_image.accept_test", "image_name", "accept_test"),
+					resource.TestCheckResourceAttr("openstack_volume_image.accept_test", "status", "active"),
+					resource.TestCheckResourceAttr("openstack_volume.accept_test", "status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckBlockStorageV1VolumeImageDestroy(s *terraform.State) error {
+	config := testAccProvider.Meta().(*Config)
+	imageClient, err := config.imageClient(OS_REGION_NAME)
+	if err != nil {
+		return fmt.Errorf("Problem getting client: %v", err)
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_volume_image" {
+			continue
+		}
+
+		_, err := getGlanceImage(imageClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Image still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccBlockStorageV1VolumeImage = fmt.Sprintf(`
+	resource "openstack_volume" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		size = 1
+	}
+
+	resource "openstack_volume_image" "accept_test" {
+		region = "%s"
+		volume_id = "${openstack_volume.accept_test.id}"
+		image_name = "accept_test"
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
@@ -0,0 +1,208 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+)
+
+func resourceVolumeQoS() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVolumeQoSCreate,
+		Read:   resourceVolumeQoSRead,
+		Update: resourceVolumeQoSUpdate,
+		Delete: resourceVolumeQoSDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			// qos specs can't be renamed
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"consumer": &schema.Schema{
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "back-end",
+				ValidateFunc: validateVolumeQoSConsumer,
+			},
+
+			"specs": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			"volume_type_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set: func(v interface{}) int {
+					return hashcode.String(v.(string))
+				},
+			},
+		},
+	}
+}
+
+func resourceVolumeQoSCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	// the specs are sent next to the name and consumer
+	opts := getVolumeQoSSpecs(d)
+	opts["name"] = d.Get("name").(string)
+	opts["consumer"] = d.Get("consumer").(string)
+
+	log.Printf("[INFO] QoS create options: %v", opts)
+
+	qos, err := createVolumeQoS(client, opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(qos.Id)
+
+	for _, v := range d.Get("volume_type_ids").(*schema.Set).List() {
+		if err := updateVolumeQoSAssociation(client, d.Id(), "associate", v.(string)); err != nil {
+			return fmt.Errorf("Error associating volume type %s with QoS %s: %v", v, d.Id(), err)
+		}
+	}
+
+	return resourceVolumeQoSRead(d, meta)
+}
+
+func resourceVolumeQoSRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	qos, err := getVolumeQoS(client, d.Id())
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] QoS info: %v", qos)
+
+	d.Set("name", qos.Name)
+	d.Set("consumer", qos.Consumer)
+	d.Set("specs", qos.Specs)
+
+	associations, err := listVolumeQoSAssociations(client, d.Id())
+	if err != nil {
+		return err
+	}
+
+	var typeIds []string
+	for _, a := range associations {
+		if a.AssociationType == "volume_type" {
+			typeIds = append(typeIds, a.Id)
+		}
+	}
+	d.Set("volume_type_ids", typeIds)
+
+	return nil
+}
+
+func resourceVolumeQoSUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	if d.HasChange("consumer") || d.HasChange("specs") {
+		o, _ := d.GetChange("specs")
+
+		specs := getVolumeQoSSpecs(d)
+		specs["consumer"] = d.Get("consumer").(string)
+
+		log.Printf("[INFO] QoS update options: %v", specs)
+
+		if err := updateVolumeQoS(client, d.Id(), specs); err != nil {
+			return err
+		}
+
+		var removed []string
+		for k := range o.(map[string]interface{}) {
+			if _, ok := specs[k]; !ok {
+				removed = append(removed, k)
+			}
+		}
+		if len(removed) > 0 {
+			if err := deleteVolumeQoSKeys(client, d.Id(), removed); err != nil {
+				return fmt.Errorf("Error deleting specs %v of QoS %s: %v", removed, d.Id(), err)
+			}
+		}
+	}
+
+	if d.HasChange("volume_type_ids") {
+		o, n := d.GetChange("volume_type_ids")
+		oldTypes := o.(*schema.Set)
+		newTypes := n.(*schema.Set)
+
+		for _, v := range oldTypes.Difference(newTypes).List() {
+			if err := updateVolumeQoSAssociation(client, d.Id(), "disassociate", v.(string)); err != nil {
+				return fmt.Errorf("Error disassociating volume type %s from QoS %s: %v", v, d.Id(), err)
+			}
+		}
+
+		for _, v := range newTypes.Difference(oldTypes).List() {
+			if err := updateVolumeQoSAssociation(client, d.Id(), "associate", v.(string)); err != nil {
+				return fmt.Errorf("Error associating volume type %s with QoS %s: %v", v, d.Id(), err)
+			}
+		}
+	}
+
+	return resourceVolumeQoSRead(d, meta)
+}
+
+func resourceVolumeQoSDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	// cinder refuses to delete qos specs that are still in use
+	if err := updateVolumeQoSAssociation(client, d.Id(), "disassociate_all", ""); err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error disassociating QoS %s: %v", d.Id(), err)
+	}
+
+	if err := deleteVolumeQoS(client, d.Id()); err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error deleting QoS %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+func getVolumeQoSSpecs(d *schema.ResourceData) map[string]string {
+	specs := make(map[string]string)
+	for k, v := range d.Get("specs").(map[string]interface{}) {
+		specs[k] = v.(string)
+	}
+
+	return specs
+}
@@ -0,0 +1,112 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBlockStorageV1VolumeQoS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeQoSDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeQoS,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_volume_qos.accept_test", "consumer", "front-end"),
+					resource.TestCheckResourceAttr("openstack_volume_qos.accept_test", "specs.read_iops_sec", "500"),
+					resource.TestCheckResourceAttr("openstack_volume_qos.accept_test", "volume_type_ids.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeQoS_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_volume_qos.accept_test", "consumer", "both"),
+					resource.TestCheckResourceAttr("openstack_volume_qos.accept_test", "specs.%", "1"),
+					resource.TestCheckResourceAttr("openstack_volume_qos.accept_test", "specs.total_iops_sec", "1000"),
+					resource.TestCheckResourceAttr("openstack_volume_qos.accept_test", "volume_type_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockStorageV1VolumeQoS_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeQoSDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeQoS,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_volume_qos.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBlockStorageV1VolumeQoSDestroy(s *terraform.State) error {
+	blockClient, err := testBlockStorageClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_volume_qos" {
+			continue
+		}
+
+		_, err := getVolumeQoS(blockClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("QoS still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccBlockStorageV1VolumeQoS = fmt.Sprintf(`
+	resource "openstack_volume_type" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}
+
+	resource "openstack_volume_qos" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		consumer = "front-end"
+		specs {
+			read_iops_sec = "500"
+			write_iops_sec = "500"
+		}
+		volume_type_ids = ["${openstack_volume_type.accept_test.id}"]
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
+
+var testAccBlockStorageV1VolumeQoS_update = fmt.Sprintf(`
+	resource "openstack_volume_type" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+	}
+
+	resource "openstack_volume_qos" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		consumer = "both"
+		specs {
+			total_iops_sec = "1000"
+		}
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
@@ -0,0 +1,260 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/blockstorage/v1/snapshots"
+)
+
+func resourceVolumeSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVolumeSnapshotCreate,
+		Read:   resourceVolumeSnapshotRead,
+		Update: resourceVolumeSnapshotUpdate,
+		Delete: resourceVolumeSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"volume_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// force allows snapshots of attached volumes
+			"force": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"metadata": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+				Computed: true,
+			},
+
+			// read-only / exported
+			"size": &schema.Schema{
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"status": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"created_at": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVolumeSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	opts := &snapshots.CreateOpts{
+		VolumeID:    d.Get("volume_id").(string),
+		Name:        d.Get("name").(string),
+		Description: d.Get("description").(string),
+		Force:       d.Get("force").(bool),
+	}
+	if m, ok := d.GetOk("metadata"); ok {
+		opts.Metadata = m.(map[string]interface{})
+	}
+
+	log.Printf("[INFO] Snapshot create options: %v", opts)
+
+	snapshot, err := snapshots.Create(client, opts).Extract()
+	if err != nil {
+		return err
+	}
+
+	d.SetId(snapshot.ID)
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating"},
+		Target:     []string{"available"},
+		Refresh:    waitForSnapshotState(client, snapshot.ID),
+		Timeout:    30 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return err
+	}
+
+	return resourceVolumeSnapshotRead(d, meta)
+}
+
+func resourceVolumeSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	snapshot, err := snapshots.Get(client, d.Id()).Extract()
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] Snapshot info: %v", snapshot)
+
+	d.Set("volume_id", snapshot.VolumeID)
+	d.Set("name", snapshot.Name)
+	description, err := getSnapshotDescription(client, d.Id())
+	if err != nil {
+		return err
+	}
+	d.Set("description", description)
+	d.Set("metadata", snapshot.Metadata)
+	d.Set("size", snapshot.Size)
+	d.Set("status", snapshot.Status)
+	d.Set("created_at", snapshot.CreatedAt)
+
+	return nil
+}
+
+func resourceVolumeSnapshotUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	// the v1 API still uses the display_ names
+	opts := make(map[string]interface{})
+	if d.HasChange("name") {
+		opts["display_name"] = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		opts["display_description"] = d.Get("description").(string)
+	}
+
+	if len(opts) > 0 {
+		_, err := perigee.Request(
+			"PUT",
+			client.ServiceURL("snapshots", d.Id()),
+			perigee.Options{
+				MoreHeaders: client.AuthenticatedHeaders(),
+				ReqBody: map[string]interface{}{
+					"snapshot": opts,
+				},
+				OkCodes: []int{200},
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("metadata") {
+		metadata := make(map[string]string)
+		for k, v := range d.Get("metadata").(map[string]interface{}) {
+			metadata[k] = v.(string)
+		}
+
+		// replaces all of the metadata of the snapshot
+		_, err := perigee.Request(
+			"PUT",
+			client.ServiceURL("snapshots", d.Id(), "metadata"),
+			perigee.Options{
+				MoreHeaders: client.AuthenticatedHeaders(),
+				ReqBody: map[string]interface{}{
+					"metadata": metadata,
+				},
+				OkCodes: []int{200},
+			},
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceVolumeSnapshotRead(d, meta)
+}
+
+func resourceVolumeSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := snapshots.Delete(client, d.Id()).ExtractErr(); err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error deleting snapshot %s: %v", d.Id(), err)
+	}
+
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"available", "deleting"},
+		Target:     []string{"DELETED"},
+		Refresh:    waitForSnapshotState(client, d.Id()),
+		Timeout:    30 * time.Minute,
+		Delay:      5 * time.Second,
+		MinTimeout: 2 * time.Second,
+	}
+
+	if _, err := stateConf.WaitForState(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getSnapshotDescription returns the description of a snapshot.
+// gophercloud reads it from display_discription, so it is always empty.
+func getSnapshotDescription(client *gophercloud.ServiceClient, snapshotId string) (string, error) {
+	type snapshotDescription struct {
+		Description string `json:"display_description"`
+	}
+	var snapshot snapshotDescription
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("snapshots", snapshotId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Snapshot *snapshotDescription `json:"snapshot"`
+			}{&snapshot},
+			OkCodes: []int{200},
+		},
+	)
+
+	return snapshot.Description, err
+}
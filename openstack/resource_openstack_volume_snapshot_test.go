@@ -0,0 +1,95 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/rackspace/gophercloud/openstack/blockstorage/v1/snapshots"
+)
+
+func TestAccBlockStorageV1VolumeSnapshot(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeSnapshotDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeSnapshot,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_volume_snapshot.accept_test", "status", "available"),
+					resource.TestCheckResourceAttr("openstack_volume_snapshot.accept_test", "description", "accept_test"),
+					resource.TestCheckResourceAttr("openstack_volume.accept_test_clone", "status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockStorageV1VolumeSnapshot_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeSnapshotDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeSnapshot,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_volume_snapshot.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBlockStorageV1VolumeSnapshotDestroy(s *terraform.State) error {
+	blockClient, err := testBlockStorageClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_volume_snapshot" {
+			continue
+		}
+
+		_, err := snapshots.Get(blockClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Snapshot still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccBlockStorageV1VolumeSnapshot = fmt.Sprintf(`
+	resource "openstack_volume" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		size = 1
+	}
+
+	resource "openstack_volume_snapshot" "accept_test" {
+		region = "%s"
+		volume_id = "${openstack_volume.accept_test.id}"
+		name = "accept_test"
+		description = "accept_test"
+		metadata {
+			foo = "bar"
+		}
+	}
+
+	resource "openstack_volume" "accept_test_clone" {
+		region = "%s"
+		name = "accept_test_clone"
+		size = 1
+		snapshot_id = "${openstack_volume_snapshot.accept_test.id}"
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
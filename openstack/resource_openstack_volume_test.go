@@ -0,0 +1,142 @@
+package openstack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/blockstorage/v1/volumes"
+)
+
+func TestAccBlockStorageV1Volume(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1Volume_retype,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_volume.accept_test", "name", "accept_test"),
+					resource.TestCheckResourceAttr("openstack_volume.accept_test", "volume_type", "accept_test_1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccBlockStorageV1Volume_retypeUpdate,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_volume.accept_test", "name", "accept_test_updated"),
+					resource.TestCheckResourceAttr("openstack_volume.accept_test", "description", "accept_test_updated"),
+					resource.TestCheckResourceAttr("openstack_volume.accept_test", "volume_type", "accept_test_2"),
+					resource.TestCheckResourceAttr("openstack_volume.accept_test", "status", "available"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockStorageV1Volume_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1Volume,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_volume.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testBlockStorageClient() (*gophercloud.ServiceClient, error) {
+	config := testAccProvider.Meta().(*Config)
+	blockClient, err := config.blockStorageClient(OS_REGION_NAME)
+	if err != nil {
+		return nil, fmt.Errorf("Problem getting client: %v", err)
+	}
+	return blockClient, nil
+}
+
+func testAccCheckBlockStorageV1VolumeDestroy(s *terraform.State) error {
+	blockClient, err := testBlockStorageClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_volume" {
+			continue
+		}
+
+		_, err := volumes.Get(blockClient, rs.Primary.ID).Extract()
+		if err == nil {
+			return fmt.Errorf("Volume still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccBlockStorageV1Volume = fmt.Sprintf(`
+	resource "openstack_volume" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		description = "accept_test"
+		size = 1
+	}`,
+	OS_REGION_NAME,
+)
+
+var testAccBlockStorageV1Volume_retype = fmt.Sprintf(`
+	resource "openstack_volume_type" "accept_test_1" {
+		region = "%s"
+		name = "accept_test_1"
+	}
+
+	resource "openstack_volume_type" "accept_test_2" {
+		region = "%s"
+		name = "accept_test_2"
+	}
+
+	resource "openstack_volume" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		description = "accept_test"
+		size = 1
+		volume_type = "${openstack_volume_type.accept_test_1.name}"
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
+
+var testAccBlockStorageV1Volume_retypeUpdate = fmt.Sprintf(`
+	resource "openstack_volume_type" "accept_test_1" {
+		region = "%s"
+		name = "accept_test_1"
+	}
+
+	resource "openstack_volume_type" "accept_test_2" {
+		region = "%s"
+		name = "accept_test_2"
+	}
+
+	resource "openstack_volume" "accept_test" {
+		region = "%s"
+		name = "accept_test_updated"
+		description = "accept_test_updated"
+		size = 1
+		volume_type = "${openstack_volume_type.accept_test_2.name}"
+		migration_policy = "on-demand"
+	}`,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+	OS_REGION_NAME,
+)
@@ -0,0 +1,247 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/racker/perigee"
+)
+
+func resourceVolumeType() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceVolumeTypeCreate,
+		Read:   resourceVolumeTypeRead,
+		Update: resourceVolumeTypeUpdate,
+		Delete: resourceVolumeTypeDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"region": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"description": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"is_public": &schema.Schema{
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+				ForceNew: true,
+			},
+
+			"extra_specs": &schema.Schema{
+				Type:     schema.TypeMap,
+				Optional: true,
+			},
+
+			// projects that can use a private volume type
+			"project_ids": &schema.Schema{
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set: func(v interface{}) int {
+					return hashcode.String(v.(string))
+				},
+			},
+		},
+	}
+}
+
+func resourceVolumeTypeCreate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := checkVolumeTypeAccess(d); err != nil {
+		return err
+	}
+
+	opts := map[string]interface{}{
+		"name": d.Get("name").(string),
+	}
+	if v := d.Get("description").(string); v != "" {
+		opts["description"] = v
+	}
+	if specs := getVolumeTypeExtraSpecs(d); len(specs) > 0 {
+		opts["extra_specs"] = specs
+	}
+	// only sent for private types, so clouds without the
+	// os-volume-type-access extension keep working
+	if !d.Get("is_public").(bool) {
+		opts["os-volume-type-access:is_public"] = false
+	}
+
+	log.Printf("[INFO] Volume type create options: %v", opts)
+
+	volumeType, err := createVolumeType(client, opts)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(volumeType.Id)
+
+	for _, v := range d.Get("project_ids").(*schema.Set).List() {
+		if err := updateVolumeTypeAccess(client, d.Id(), "addProjectAccess", v.(string)); err != nil {
+			return fmt.Errorf("Error adding project %s to volume type %s: %v", v, d.Id(), err)
+		}
+	}
+
+	return resourceVolumeTypeRead(d, meta)
+}
+
+func resourceVolumeTypeRead(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	volumeType, err := getVolumeType(client, d.Id())
+	if err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+	log.Printf("[INFO] Volume type info: %v", volumeType)
+
+	d.Set("name", volumeType.Name)
+	d.Set("description", volumeType.Description)
+	d.Set("extra_specs", volumeType.ExtraSpecs)
+
+	isPublic := true
+	if volumeType.IsPublic != nil {
+		isPublic = *volumeType.IsPublic
+	}
+	d.Set("is_public", isPublic)
+
+	// cinder has no access list for public types
+	var projectIds []string
+	if !isPublic {
+		access, err := listVolumeTypeAccess(client, d.Id())
+		if err != nil {
+			return err
+		}
+		for _, a := range access {
+			projectIds = append(projectIds, a.ProjectId)
+		}
+	}
+	d.Set("project_ids", projectIds)
+
+	return nil
+}
+
+func resourceVolumeTypeUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := checkVolumeTypeAccess(d); err != nil {
+		return err
+	}
+
+	opts := make(map[string]interface{})
+	if d.HasChange("name") {
+		opts["name"] = d.Get("name").(string)
+	}
+	if d.HasChange("description") {
+		opts["description"] = d.Get("description").(string)
+	}
+
+	if len(opts) > 0 {
+		log.Printf("[INFO] Volume type update options: %v", opts)
+
+		if err := updateVolumeType(client, d.Id(), opts); err != nil {
+			return err
+		}
+	}
+
+	if d.HasChange("extra_specs") {
+		o, _ := d.GetChange("extra_specs")
+
+		specs := getVolumeTypeExtraSpecs(d)
+		if len(specs) > 0 {
+			if err := setVolumeTypeExtraSpecs(client, d.Id(), specs); err != nil {
+				return err
+			}
+		}
+
+		for k := range o.(map[string]interface{}) {
+			if _, ok := specs[k]; ok {
+				continue
+			}
+			if err := deleteVolumeTypeExtraSpec(client, d.Id(), k); err != nil {
+				return fmt.Errorf("Error deleting extra spec %s of volume type %s: %v", k, d.Id(), err)
+			}
+		}
+	}
+
+	if d.HasChange("project_ids") {
+		o, n := d.GetChange("project_ids")
+		oldProjects := o.(*schema.Set)
+		newProjects := n.(*schema.Set)
+
+		for _, v := range oldProjects.Difference(newProjects).List() {
+			if err := updateVolumeTypeAccess(client, d.Id(), "removeProjectAccess", v.(string)); err != nil {
+				return fmt.Errorf("Error removing project %s from volume type %s: %v", v, d.Id(), err)
+			}
+		}
+
+		for _, v := range newProjects.Difference(oldProjects).List() {
+			if err := updateVolumeTypeAccess(client, d.Id(), "addProjectAccess", v.(string)); err != nil {
+				return fmt.Errorf("Error adding project %s to volume type %s: %v", v, d.Id(), err)
+			}
+		}
+	}
+
+	return resourceVolumeTypeRead(d, meta)
+}
+
+func resourceVolumeTypeDelete(d *schema.ResourceData, meta interface{}) error {
+	client, err := getClient("block", d, meta)
+	if err != nil {
+		return err
+	}
+
+	if err := deleteVolumeType(client, d.Id()); err != nil {
+		if httpStatus, ok := err.(*perigee.UnexpectedResponseCodeError); ok && httpStatus.Actual == 404 {
+			return nil
+		}
+		return fmt.Errorf("Error deleting volume type %s: %v", d.Id(), err)
+	}
+
+	return nil
+}
+
+func checkVolumeTypeAccess(d *schema.ResourceData) error {
+	if d.Get("is_public").(bool) && d.Get("project_ids").(*schema.Set).Len() > 0 {
+		return fmt.Errorf("project_ids can only be used when is_public is false.")
+	}
+
+	return nil
+}
+
+func getVolumeTypeExtraSpecs(d *schema.ResourceData) map[string]string {
+	specs := make(map[string]string)
+	for k, v := range d.Get("extra_specs").(map[string]interface{}) {
+		specs[k] = v.(string)
+	}
+
+	return specs
+}
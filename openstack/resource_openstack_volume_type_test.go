@@ -0,0 +1,103 @@
+package openstack
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccBlockStorageV1VolumeType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeTypeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeType,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_volume_type.accept_test", "name", "accept_test"),
+					resource.TestCheckResourceAttr("openstack_volume_type.accept_test", "extra_specs.%", "2"),
+					resource.TestCheckResourceAttr("openstack_volume_type.accept_test", "extra_specs.foo", "bar"),
+					resource.TestCheckResourceAttr("openstack_volume_type.accept_test", "project_ids.#", "1"),
+				),
+			},
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeType_update,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("openstack_volume_type.accept_test", "name", "accept_test_updated"),
+					resource.TestCheckResourceAttr("openstack_volume_type.accept_test", "extra_specs.%", "1"),
+					resource.TestCheckResourceAttr("openstack_volume_type.accept_test", "extra_specs.foo", "baz"),
+					resource.TestCheckResourceAttr("openstack_volume_type.accept_test", "project_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBlockStorageV1VolumeType_importBasic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckBlockStorageV1VolumeTypeDestroy,
+		Steps: []resource.TestStep{
+			resource.TestStep{
+				Config: testAccBlockStorageV1VolumeType,
+			},
+			resource.TestStep{
+				ResourceName:      "openstack_volume_type.accept_test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckBlockStorageV1VolumeTypeDestroy(s *terraform.State) error {
+	blockClient, err := testBlockStorageClient()
+	if err != nil {
+		return err
+	}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "openstack_volume_type" {
+			continue
+		}
+
+		_, err := getVolumeType(blockClient, rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("Volume type still exists.")
+		}
+	}
+
+	return nil
+}
+
+var testAccBlockStorageV1VolumeType = fmt.Sprintf(`
+	resource "openstack_volume_type" "accept_test" {
+		region = "%s"
+		name = "accept_test"
+		is_public = false
+		project_ids = ["%s"]
+		extra_specs {
+			foo = "bar"
+			volume_backend_name = "accept_test"
+		}
+	}`,
+	OS_REGION_NAME,
+	os.Getenv("OS_TENANT_ID"),
+)
+
+var testAccBlockStorageV1VolumeType_update = fmt.Sprintf(`
+	resource "openstack_volume_type" "accept_test" {
+		region = "%s"
+		name = "accept_test_updated"
+		is_public = false
+		extra_specs {
+			foo = "baz"
+		}
+	}`,
+	OS_REGION_NAME,
+)
@@ -0,0 +1,175 @@
+package openstack
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/rackspace/gophercloud"
+	"github.com/rackspace/gophercloud/openstack/compute/v2/extensions/secgroups"
+	"github.com/rackspace/gophercloud/openstack/compute/v2/servers"
+	"github.com/rackspace/gophercloud/openstack/networking/v2/ports"
+	"github.com/rackspace/gophercloud/pagination"
+)
+
+// getSecgroupServers returns the instances that use a nova security group.
+// Instances only report the names of their security groups.
+func getSecgroupServers(client *gophercloud.ServiceClient, name string) ([]servers.Server, error) {
+	var users []servers.Server
+	err := servers.List(client, servers.ListOpts{}).EachPage(func(page pagination.Page) (bool, error) {
+		serverList, err := servers.ExtractServers(page)
+		if err != nil {
+			return false, err
+		}
+
+		for _, s := range serverList {
+			for _, sg := range s.SecurityGroups {
+				if sg["name"] == name {
+					users = append(users, s)
+					break
+				}
+			}
+		}
+		return true, nil
+	})
+
+	return users, err
+}
+
+// portSecgroupListOpts lists the ports that use a security group.
+// gophercloud's ports.ListOpts has no security group filter.
+type portSecgroupListOpts struct {
+	SecurityGroupID string
+}
+
+func (opts portSecgroupListOpts) ToPortListQuery() (string, error) {
+	return "?security_groups=" + url.QueryEscape(opts.SecurityGroupID), nil
+}
+
+// getNeutronSecgroupPorts returns the ports that use a neutron security
+// group. The ports are filtered by neutron, and again here for neutron
+// versions that ignore the filter.
+func getNeutronSecgroupPorts(client *gophercloud.ServiceClient, sgID string) ([]ports.Port, error) {
+	var users []ports.Port
+	err := ports.List(client, portSecgroupListOpts{sgID}).EachPage(func(page pagination.Page) (bool, error) {
+		portList, err := ports.ExtractPorts(page)
+		if err != nil {
+			return false, err
+		}
+
+		for _, p := range portList {
+			for _, id := range p.SecurityGroups {
+				if id == sgID {
+					users = append(users, p)
+					break
+				}
+			}
+		}
+		return true, nil
+	})
+
+	return users, err
+}
+
+// getSecgroupUsers describes everything that still uses a security group.
+func getSecgroupUsers(d *schema.ResourceData, meta interface{}) ([]string, error) {
+	var users []string
+
+	if d.Get("network_service") == "neutron" {
+		client, err := getClient("network", d, meta)
+		if err != nil {
+			return nil, err
+		}
+
+		sgPorts, err := getNeutronSecgroupPorts(client, d.Id())
+		if err != nil {
+			return nil, err
+		}
+
+		for _, p := range sgPorts {
+			users = append(users, fmt.Sprintf("port %s (device %s)", p.ID, p.DeviceID))
+		}
+
+		return users, nil
+	}
+
+	client, err := getClient("compute", d, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	sgServers, err := getSecgroupServers(client, d.Get("name").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range sgServers {
+		users = append(users, fmt.Sprintf("instance %s (%s)", s.Name, s.ID))
+	}
+
+	return users, nil
+}
+
+// detachSecgroup removes a security group from everything that uses it.
+func detachSecgroup(d *schema.ResourceData, meta interface{}) error {
+	if d.Get("network_service") == "neutron" {
+		client, err := getClient("network", d, meta)
+		if err != nil {
+			return err
+		}
+
+		sgPorts, err := getNeutronSecgroupPorts(client, d.Id())
+		if err != nil {
+			return err
+		}
+
+		for _, p := range sgPorts {
+			var remaining []string
+			for _, id := range p.SecurityGroups {
+				if id != d.Id() {
+					remaining = append(remaining, id)
+				}
+			}
+
+			log.Printf("[INFO] Removing security group %s from port %s", d.Id(), p.ID)
+			if err := updateNeutronPortSecgroups(client, p.ID, remaining); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	client, err := getClient("compute", d, meta)
+	if err != nil {
+		return err
+	}
+
+	name := d.Get("name").(string)
+	sgServers, err := getSecgroupServers(client, name)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range sgServers {
+		log.Printf("[INFO] Removing security group %s from instance %s", name, s.ID)
+		if err := secgroups.RemoveServerFromGroup(client, s.ID, name).ExtractErr(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateNeutronPortSecgroups sets the security groups of a port. The list
+// is always sent, so a port can be left without any security groups.
+func updateNeutronPortSecgroups(client *gophercloud.ServiceClient, portId string, sgIDs []string) error {
+	if sgIDs == nil {
+		sgIDs = []string{}
+	}
+
+	return updateNeutronPort(client, portId, map[string]interface{}{
+		"security_groups": sgIDs,
+	})
+}
@@ -0,0 +1,263 @@
+package openstack
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// validateSecgroupRuleProtocol allows tcp, udp and icmp. Nova lowercases
+// the protocol, which would change the hash of the rule, and gophercloud's
+// neutron rules only support these three.
+func validateSecgroupRuleProtocol(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "tcp" && value != "udp" && value != "icmp" {
+		errors = append(errors, fmt.Errorf("%q must be tcp, udp or icmp: %q", k, value))
+	}
+
+	return
+}
+
+// validateSecgroupRulePort allows ports and icmp types and codes.
+// -1 is the icmp wildcard.
+func validateSecgroupRulePort(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value < -1 || value > 65535 {
+		errors = append(errors, fmt.Errorf(
+			"%q must be between 0 and 65535, or -1 for icmp: %d", k, value))
+	}
+
+	return
+}
+
+func validateSecgroupRuleCIDR(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid IPv4 or IPv6 CIDR: %v", k, err))
+	}
+
+	return
+}
+
+func validateSecgroupRuleDirection(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "ingress" && value != "egress" {
+		errors = append(errors, fmt.Errorf("%q must be ingress or egress: %q", k, value))
+	}
+
+	return
+}
+
+func validateSecgroupRuleEtherType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "" && value != "IPv4" && value != "IPv6" {
+		errors = append(errors, fmt.Errorf("%q must be IPv4 or IPv6: %q", k, value))
+	}
+
+	return
+}
+
+// validateSecgroupRule checks the combinations of rule arguments that
+// can't be validated one field at a time. It runs during plan through
+// validateSecgroupRuleConfig, and again when the rules are created for
+// rules that use values only known at apply.
+func validateSecgroupRule(rule map[string]interface{}) error {
+	protocol := rule["protocol"].(string)
+	fromPort := rule["from_port"].(int)
+	toPort := rule["to_port"].(int)
+	cidr := rule["cidr"].(string)
+	sourceGroup := rule["source_group"].(string)
+
+	if (cidr == "") == (sourceGroup == "") {
+		return fmt.Errorf("Exactly one of cidr or source_group must be set.")
+	}
+
+	if etherType, ok := rule["ethertype"].(string); ok && etherType != "" && cidr != "" {
+		if isIPv6 := strings.Contains(cidr, ":"); isIPv6 != (etherType == "IPv6") {
+			return fmt.Errorf("cidr %s does not match ethertype %s.", cidr, etherType)
+		}
+	}
+
+	switch protocol {
+	case "icmp":
+		// from_port is the icmp type and to_port the icmp code
+		if fromPort < -1 || fromPort > 255 || toPort < -1 || toPort > 255 {
+			return fmt.Errorf("icmp type and code must be between -1 and 255.")
+		}
+		if fromPort == -1 && toPort != -1 {
+			return fmt.Errorf("icmp code must be -1 when icmp type is -1.")
+		}
+	case "tcp", "udp":
+		if fromPort < 0 || toPort < 0 {
+			return fmt.Errorf("Ports must be between 0 and 65535 for %s.", protocol)
+		}
+		if fromPort > toPort {
+			return fmt.Errorf("from_port (%d) must not be greater than to_port (%d).", fromPort, toPort)
+		}
+	}
+
+	return nil
+}
+
+// validateSecgroupRuleConfig runs validateSecgroupRule on the rule whose
+// arguments start with prefix. A computed cidr or source_group only
+// counts as set, usually the ID of another group. Rules with other
+// computed arguments are skipped, as are arguments of the wrong type,
+// which the schema reports.
+func validateSecgroupRuleConfig(c *terraform.ResourceConfig, prefix string) error {
+	rule := map[string]interface{}{
+		"protocol":     "",
+		"from_port":    0,
+		"to_port":      0,
+		"cidr":         "",
+		"source_group": "",
+		"ethertype":    "",
+	}
+
+	cidrComputed := false
+	for k, zero := range rule {
+		v, ok := c.Get(prefix + k)
+		if !ok && !c.IsComputed(prefix+k) {
+			continue
+		}
+
+		s, isString := v.(string)
+		if !ok || isString && (s == config.UnknownVariableValue || strings.Contains(s, "${")) {
+			switch k {
+			case "cidr":
+				cidrComputed = true
+				rule[k] = "computed"
+			case "source_group":
+				rule[k] = "computed"
+			default:
+				return nil
+			}
+			continue
+		}
+
+		switch zero.(type) {
+		case int:
+			if n, isInt := v.(int); isInt {
+				rule[k] = n
+			} else if n, err := strconv.Atoi(s); isString && err == nil {
+				rule[k] = n
+			} else {
+				return nil
+			}
+		case string:
+			if !isString {
+				return nil
+			}
+			rule[k] = s
+		}
+	}
+
+	// the ethertype can't be checked against a computed cidr
+	if cidrComputed {
+		rule["ethertype"] = ""
+	}
+
+	return validateSecgroupRule(rule)
+}
+
+// validateSecgroupRuleConfigs validates each inline rule of a security
+// group.
+func validateSecgroupRuleConfigs(c *terraform.ResourceConfig) (errors []error) {
+	v, _ := c.Get("rule.#")
+	n, _ := v.(int)
+
+	for i := 0; i < n; i++ {
+		if err := validateSecgroupRuleConfig(c, fmt.Sprintf("rule.%d.", i)); err != nil {
+			errors = append(errors, fmt.Errorf("rule %d: %v", i, err))
+		}
+	}
+
+	return
+}
+
+func validateCIDR(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid IPv4 or IPv6 CIDR: %v", k, err))
+	}
+
+	return
+}
+
+func validateIPAddress(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value == "" {
+		return
+	}
+
+	if net.ParseIP(value) == nil {
+		errors = append(errors, fmt.Errorf("%q must be a valid IPv4 or IPv6 address: %q", k, value))
+	}
+
+	return
+}
+
+func validateSubnetIPVersion(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(int)
+	if value != 4 && value != 6 {
+		errors = append(errors, fmt.Errorf("%q must be 4 or 6: %d", k, value))
+	}
+
+	return
+}
+
+func validateSubnetIPv6Mode(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	switch value {
+	case "", "slaac", "dhcpv6-stateful", "dhcpv6-stateless":
+		return
+	}
+
+	errors = append(errors, fmt.Errorf(
+		"%q must be slaac, dhcpv6-stateful or dhcpv6-stateless: %q", k, value))
+
+	return
+}
+
+func validatePortVNICType(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	switch value {
+	case "", "normal", "direct", "direct-physical", "macvtap", "baremetal", "virtio-forwarder":
+		return
+	}
+
+	errors = append(errors, fmt.Errorf(
+		"%q must be normal, direct, direct-physical, macvtap, baremetal or virtio-forwarder: %q", k, value))
+
+	return
+}
+
+func validateVolumeQoSConsumer(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	switch value {
+	case "front-end", "back-end", "both":
+		return
+	}
+
+	errors = append(errors, fmt.Errorf(
+		"%q must be front-end, back-end or both: %q", k, value))
+
+	return
+}
+
+func validateVolumeMigrationPolicy(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if value != "never" && value != "on-demand" {
+		errors = append(errors, fmt.Errorf("%q must be never or on-demand: %q", k, value))
+	}
+
+	return
+}
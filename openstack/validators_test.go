@@ -0,0 +1,142 @@
+package openstack
+
+import (
+	"testing"
+)
+
+func TestValidateSecgroupRuleProtocol(t *testing.T) {
+	valid := []string{"tcp", "udp", "icmp"}
+	for _, v := range valid {
+		if _, errors := validateSecgroupRuleProtocol(v, "protocol"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid protocol: %v", v, errors)
+		}
+	}
+
+	invalid := []string{"", "ssh", "TCP", "Udp", "0", "47", "-1", "256"}
+	for _, v := range invalid {
+		if _, errors := validateSecgroupRuleProtocol(v, "protocol"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid protocol", v)
+		}
+	}
+}
+
+func TestValidateSecgroupRulePort(t *testing.T) {
+	valid := []int{-1, 0, 22, 65535}
+	for _, v := range valid {
+		if _, errors := validateSecgroupRulePort(v, "from_port"); len(errors) != 0 {
+			t.Fatalf("%d should be a valid port: %v", v, errors)
+		}
+	}
+
+	invalid := []int{-2, 65536}
+	for _, v := range invalid {
+		if _, errors := validateSecgroupRulePort(v, "from_port"); len(errors) == 0 {
+			t.Fatalf("%d should be an invalid port", v)
+		}
+	}
+}
+
+func TestValidateSecgroupRuleCIDR(t *testing.T) {
+	valid := []string{"", "0.0.0.0/0", "10.0.0.0/8", "::/0", "2001:db8::/32"}
+	for _, v := range valid {
+		if _, errors := validateSecgroupRuleCIDR(v, "cidr"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid cidr: %v", v, errors)
+		}
+	}
+
+	invalid := []string{"10.0.0.0", "10.0.0.0/33", "::/129", "foo"}
+	for _, v := range invalid {
+		if _, errors := validateSecgroupRuleCIDR(v, "cidr"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid cidr", v)
+		}
+	}
+}
+
+func TestValidateSecgroupRule(t *testing.T) {
+	rule := func(protocol string, from, to int, cidr, sourceGroup, etherType string) map[string]interface{} {
+		return map[string]interface{}{
+			"protocol":     protocol,
+			"from_port":    from,
+			"to_port":      to,
+			"cidr":         cidr,
+			"source_group": sourceGroup,
+			"ethertype":    etherType,
+		}
+	}
+
+	valid := []map[string]interface{}{
+		rule("tcp", 22, 22, "0.0.0.0/0", "", ""),
+		rule("udp", 1, 65535, "::/0", "", "IPv6"),
+		rule("icmp", -1, -1, "0.0.0.0/0", "", ""),
+		rule("icmp", 8, 0, "", "sg-id", ""),
+		rule("tcp", 0, 65535, "10.0.0.0/8", "", "IPv4"),
+	}
+	for _, r := range valid {
+		if err := validateSecgroupRule(r); err != nil {
+			t.Fatalf("%v should be a valid rule: %v", r, err)
+		}
+	}
+
+	invalid := []map[string]interface{}{
+		rule("tcp", 22, 22, "", "", ""),
+		rule("tcp", 22, 22, "0.0.0.0/0", "sg-id", ""),
+		rule("tcp", 443, 80, "0.0.0.0/0", "", ""),
+		rule("tcp", -1, 80, "0.0.0.0/0", "", ""),
+		rule("icmp", -1, 0, "0.0.0.0/0", "", ""),
+		rule("icmp", 256, 0, "0.0.0.0/0", "", ""),
+		rule("tcp", 22, 22, "::/0", "", "IPv4"),
+	}
+	for _, r := range invalid {
+		if err := validateSecgroupRule(r); err == nil {
+			t.Fatalf("%v should be an invalid rule", r)
+		}
+	}
+}
+
+func TestValidateSubnetIPv6Mode(t *testing.T) {
+	valid := []string{"", "slaac", "dhcpv6-stateful", "dhcpv6-stateless"}
+	for _, v := range valid {
+		if _, errors := validateSubnetIPv6Mode(v, "ipv6_ra_mode"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid ipv6 mode: %v", v, errors)
+		}
+	}
+
+	invalid := []string{"dhcpv6", "SLAAC"}
+	for _, v := range invalid {
+		if _, errors := validateSubnetIPv6Mode(v, "ipv6_ra_mode"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid ipv6 mode", v)
+		}
+	}
+}
+
+func TestValidateVolumeQoSConsumer(t *testing.T) {
+	valid := []string{"front-end", "back-end", "both"}
+	for _, v := range valid {
+		if _, errors := validateVolumeQoSConsumer(v, "consumer"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid consumer: %v", v, errors)
+		}
+	}
+
+	invalid := []string{"", "frontend", "Both"}
+	for _, v := range invalid {
+		if _, errors := validateVolumeQoSConsumer(v, "consumer"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid consumer", v)
+		}
+	}
+}
+
+func TestValidateVolumeMigrationPolicy(t *testing.T) {
+	valid := []string{"never", "on-demand"}
+	for _, v := range valid {
+		if _, errors := validateVolumeMigrationPolicy(v, "migration_policy"); len(errors) != 0 {
+			t.Fatalf("%q should be a valid migration policy: %v", v, errors)
+		}
+	}
+
+	invalid := []string{"", "always", "ondemand"}
+	for _, v := range invalid {
+		if _, errors := validateVolumeMigrationPolicy(v, "migration_policy"); len(errors) == 0 {
+			t.Fatalf("%q should be an invalid migration policy", v)
+		}
+	}
+}
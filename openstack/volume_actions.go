@@ -0,0 +1,123 @@
+package openstack
+
+import (
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+)
+
+// gophercloud has no support for updating, retyping or uploading volumes,
+// and its volumes don't have a migration status.
+
+type VolumeMigration struct {
+	Id              string `json:"id"`
+	Status          string `json:"status"`
+	VolumeType      string `json:"volume_type"`
+	MigrationStatus string `json:"os-vol-mig-status-attr:migstat"`
+}
+
+// updateVolume changes the name and description of a volume. The v1 API
+// still uses the display_ names.
+func updateVolume(client *gophercloud.ServiceClient, volumeId string, opts map[string]interface{}) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("volumes", volumeId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"volume": opts,
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
+
+// updateVolumeMetadata replaces all of the metadata of a volume.
+func updateVolumeMetadata(client *gophercloud.ServiceClient, volumeId string, metadata map[string]string) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("volumes", volumeId, "metadata"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"metadata": metadata,
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
+
+// retypeVolume changes the volume type of a volume. migrationPolicy is
+// either never or on-demand.
+func retypeVolume(client *gophercloud.ServiceClient, volumeId, newType, migrationPolicy string) error {
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("volumes", volumeId, "action"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"os-retype": map[string]string{
+					"new_type":         newType,
+					"migration_policy": migrationPolicy,
+				},
+			},
+			OkCodes: []int{202},
+		},
+	)
+
+	return err
+}
+
+// getVolumeMigration returns the status and migration status of a volume.
+// The migration status is only shown to admins with the default Cinder
+// policy.
+func getVolumeMigration(client *gophercloud.ServiceClient, volumeId string) (*VolumeMigration, error) {
+	var volume VolumeMigration
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("volumes", volumeId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Volume *VolumeMigration `json:"volume"`
+			}{&volume},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &volume, err
+}
+
+type VolumeImageUpload struct {
+	ImageId         string `json:"image_id"`
+	ImageName       string `json:"image_name"`
+	DiskFormat      string `json:"disk_format"`
+	ContainerFormat string `json:"container_format"`
+	Status          string `json:"status"`
+}
+
+// uploadVolumeImage copies a volume to a new glance image.
+func uploadVolumeImage(client *gophercloud.ServiceClient, volumeId string, opts map[string]interface{}) (*VolumeImageUpload, error) {
+	var upload VolumeImageUpload
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("volumes", volumeId, "action"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"os-volume_upload_image": opts,
+			},
+			Results: &struct {
+				Upload *VolumeImageUpload `json:"os-volume_upload_image"`
+			}{&upload},
+			OkCodes: []int{202},
+		},
+	)
+
+	return &upload, err
+}
@@ -0,0 +1,105 @@
+package openstack
+
+import (
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+)
+
+// gophercloud has no support for cinder backups.
+
+type VolumeBackup struct {
+	Id            string `json:"id"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	VolumeId      string `json:"volume_id"`
+	Container     string `json:"container"`
+	Status        string `json:"status"`
+	FailReason    string `json:"fail_reason"`
+	Size          int    `json:"size"`
+	IsIncremental bool   `json:"is_incremental"`
+	ObjectCount   int    `json:"object_count"`
+	CreatedAt     string `json:"created_at"`
+}
+
+type VolumeBackupRestore struct {
+	BackupId   string `json:"backup_id"`
+	VolumeId   string `json:"volume_id"`
+	VolumeName string `json:"volume_name"`
+}
+
+func createVolumeBackup(client *gophercloud.ServiceClient, opts map[string]interface{}) (*VolumeBackup, error) {
+	var backup VolumeBackup
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("backups"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"backup": opts,
+			},
+			Results: &struct {
+				Backup *VolumeBackup `json:"backup"`
+			}{&backup},
+			OkCodes: []int{202},
+		},
+	)
+
+	return &backup, err
+}
+
+func getVolumeBackup(client *gophercloud.ServiceClient, backupId string) (*VolumeBackup, error) {
+	var backup VolumeBackup
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("backups", backupId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Backup *VolumeBackup `json:"backup"`
+			}{&backup},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &backup, err
+}
+
+func deleteVolumeBackup(client *gophercloud.ServiceClient, backupId string) error {
+	_, err := perigee.Request(
+		"DELETE",
+		client.ServiceURL("backups", backupId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			OkCodes:     []int{202},
+		},
+	)
+
+	return err
+}
+
+// restoreVolumeBackup restores a backup onto an existing volume, which
+// must be available and at least as large as the backup.
+func restoreVolumeBackup(client *gophercloud.ServiceClient, backupId, volumeId string) (*VolumeBackupRestore, error) {
+	var restore VolumeBackupRestore
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("backups", backupId, "restore"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"restore": map[string]string{
+					"volume_id": volumeId,
+				},
+			},
+			Results: &struct {
+				Restore *VolumeBackupRestore `json:"restore"`
+			}{&restore},
+			OkCodes: []int{202},
+		},
+	)
+
+	return &restore, err
+}
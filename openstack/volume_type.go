@@ -0,0 +1,299 @@
+package openstack
+
+import (
+	"net/url"
+
+	"github.com/racker/perigee"
+	"github.com/rackspace/gophercloud"
+)
+
+// gophercloud has no support for volume types or qos specs.
+
+type VolumeType struct {
+	Id          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	ExtraSpecs  map[string]string `json:"extra_specs"`
+	IsPublic    *bool             `json:"os-volume-type-access:is_public"`
+}
+
+type VolumeTypeAccess struct {
+	VolumeTypeId string `json:"volume_type_id"`
+	ProjectId    string `json:"project_id"`
+}
+
+type VolumeQoS struct {
+	Id       string            `json:"id"`
+	Name     string            `json:"name"`
+	Consumer string            `json:"consumer"`
+	Specs    map[string]string `json:"specs"`
+}
+
+type VolumeQoSAssociation struct {
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	AssociationType string `json:"association_type"`
+}
+
+func createVolumeType(client *gophercloud.ServiceClient, opts map[string]interface{}) (*VolumeType, error) {
+	var volumeType VolumeType
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("types"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"volume_type": opts,
+			},
+			Results: &struct {
+				VolumeType *VolumeType `json:"volume_type"`
+			}{&volumeType},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &volumeType, err
+}
+
+func getVolumeType(client *gophercloud.ServiceClient, typeId string) (*VolumeType, error) {
+	var volumeType VolumeType
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("types", typeId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				VolumeType *VolumeType `json:"volume_type"`
+			}{&volumeType},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &volumeType, err
+}
+
+func updateVolumeType(client *gophercloud.ServiceClient, typeId string, opts map[string]interface{}) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("types", typeId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"volume_type": opts,
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
+
+func deleteVolumeType(client *gophercloud.ServiceClient, typeId string) error {
+	_, err := perigee.Request(
+		"DELETE",
+		client.ServiceURL("types", typeId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			OkCodes:     []int{202},
+		},
+	)
+
+	return err
+}
+
+// setVolumeTypeExtraSpecs creates or updates the given extra specs. Other
+// extra specs of the type are left alone.
+func setVolumeTypeExtraSpecs(client *gophercloud.ServiceClient, typeId string, specs map[string]string) error {
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("types", typeId, "extra_specs"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"extra_specs": specs,
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
+
+func deleteVolumeTypeExtraSpec(client *gophercloud.ServiceClient, typeId, key string) error {
+	_, err := perigee.Request(
+		"DELETE",
+		client.ServiceURL("types", typeId, "extra_specs", key),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			OkCodes:     []int{202},
+		},
+	)
+
+	return err
+}
+
+func listVolumeTypeAccess(client *gophercloud.ServiceClient, typeId string) ([]VolumeTypeAccess, error) {
+	var access []VolumeTypeAccess
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("types", typeId, "os-volume-type-access"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Access *[]VolumeTypeAccess `json:"volume_type_access"`
+			}{&access},
+			OkCodes: []int{200},
+		},
+	)
+
+	return access, err
+}
+
+// updateVolumeTypeAccess grants or revokes the access of a project to a
+// private volume type. action is either addProjectAccess or
+// removeProjectAccess.
+func updateVolumeTypeAccess(client *gophercloud.ServiceClient, typeId, action, projectId string) error {
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("types", typeId, "action"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				action: map[string]string{
+					"project": projectId,
+				},
+			},
+			OkCodes: []int{202},
+		},
+	)
+
+	return err
+}
+
+func createVolumeQoS(client *gophercloud.ServiceClient, opts map[string]string) (*VolumeQoS, error) {
+	var qos VolumeQoS
+
+	_, err := perigee.Request(
+		"POST",
+		client.ServiceURL("qos-specs"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"qos_specs": opts,
+			},
+			Results: &struct {
+				QoS *VolumeQoS `json:"qos_specs"`
+			}{&qos},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &qos, err
+}
+
+func getVolumeQoS(client *gophercloud.ServiceClient, qosId string) (*VolumeQoS, error) {
+	var qos VolumeQoS
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("qos-specs", qosId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				QoS *VolumeQoS `json:"qos_specs"`
+			}{&qos},
+			OkCodes: []int{200},
+		},
+	)
+
+	return &qos, err
+}
+
+// updateVolumeQoS creates or updates the given specs. The consumer is
+// changed by passing it as a spec.
+func updateVolumeQoS(client *gophercloud.ServiceClient, qosId string, specs map[string]string) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("qos-specs", qosId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"qos_specs": specs,
+			},
+			OkCodes: []int{200},
+		},
+	)
+
+	return err
+}
+
+func deleteVolumeQoSKeys(client *gophercloud.ServiceClient, qosId string, keys []string) error {
+	_, err := perigee.Request(
+		"PUT",
+		client.ServiceURL("qos-specs", qosId, "delete_keys"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			ReqBody: map[string]interface{}{
+				"keys": keys,
+			},
+			OkCodes: []int{202},
+		},
+	)
+
+	return err
+}
+
+func deleteVolumeQoS(client *gophercloud.ServiceClient, qosId string) error {
+	_, err := perigee.Request(
+		"DELETE",
+		client.ServiceURL("qos-specs", qosId),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			OkCodes:     []int{202},
+		},
+	)
+
+	return err
+}
+
+func listVolumeQoSAssociations(client *gophercloud.ServiceClient, qosId string) ([]VolumeQoSAssociation, error) {
+	var associations []VolumeQoSAssociation
+
+	_, err := perigee.Request(
+		"GET",
+		client.ServiceURL("qos-specs", qosId, "associations"),
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			Results: &struct {
+				Associations *[]VolumeQoSAssociation `json:"qos_associations"`
+			}{&associations},
+			OkCodes: []int{200},
+		},
+	)
+
+	return associations, err
+}
+
+// updateVolumeQoSAssociation associates or disassociates a volume type and
+// qos specs. action is either associate or disassociate. An empty typeId
+// with disassociate_all removes every association.
+func updateVolumeQoSAssociation(client *gophercloud.ServiceClient, qosId, action, typeId string) error {
+	reqURL := client.ServiceURL("qos-specs", qosId, action)
+	if typeId != "" {
+		reqURL += "?vol_type_id=" + url.QueryEscape(typeId)
+	}
+
+	_, err := perigee.Request(
+		"GET",
+		reqURL,
+		perigee.Options{
+			MoreHeaders: client.AuthenticatedHeaders(),
+			OkCodes:     []int{202},
+		},
+	)
+
+	return err
+}